@@ -27,7 +27,9 @@ import (
 	"git.golaxy.org/core/utils/corectx"
 	"git.golaxy.org/core/utils/exception"
 	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/tiny/ec"
 	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/clock"
 )
 
 // Submit 将有返回值函数投递到 provider 所属 Runtime，并返回执行结果 Future。
@@ -61,6 +63,150 @@ func PostDelegate(provider corectx.ConcurrentContextProvider, fun generic.Delega
 	return runtime.Concurrent(provider).PostDelegate(fun, args...)
 }
 
+// SubmitPriority 将有返回值函数投递到 provider 所属 Runtime 的 priority 通道。
+func SubmitPriority(provider corectx.ConcurrentContextProvider, priority runtime.TaskPriority, fun generic.FuncVar1[runtime.Context, any, async.Result], args ...any) async.Future {
+	return runtime.Concurrent(provider).SubmitPriority(priority, fun, args...)
+}
+
+// SubmitVoidPriority 将无业务返回值函数投递到 provider 所属 Runtime 的 priority 通道。
+func SubmitVoidPriority(provider corectx.ConcurrentContextProvider, priority runtime.TaskPriority, fun generic.ActionVar1[runtime.Context, any], args ...any) async.Future {
+	return runtime.Concurrent(provider).SubmitVoidPriority(priority, fun, args...)
+}
+
+// PostPriority 将无返回值函数投递到 provider 所属 Runtime 的 priority 通道，不创建 Future。
+func PostPriority(provider corectx.ConcurrentContextProvider, priority runtime.TaskPriority, fun generic.ActionVar1[runtime.Context, any], args ...any) error {
+	return runtime.Concurrent(provider).PostPriority(priority, fun, args...)
+}
+
+// SubmitCtx 将有返回值函数投递到 provider 所属 Runtime。
+// ctx 在任务开始执行前结束时，任务被跳过，Future 以 ctx.Err 完成。
+func SubmitCtx(provider corectx.ConcurrentContextProvider, ctx context.Context, fun generic.FuncVar1[runtime.Context, any, async.Result], args ...any) async.Future {
+	return runtime.Concurrent(provider).SubmitCtx(ctx, fun, args...)
+}
+
+// SubmitVoidCtx 将无业务返回值函数投递到 provider 所属 Runtime；ctx 语义同 SubmitCtx。
+func SubmitVoidCtx(provider corectx.ConcurrentContextProvider, ctx context.Context, fun generic.ActionVar1[runtime.Context, any], args ...any) async.Future {
+	return runtime.Concurrent(provider).SubmitVoidCtx(ctx, fun, args...)
+}
+
+// SubmitWait 将有返回值函数投递到 provider 所属 Runtime。
+// 有界队列已满时阻塞等待容量，直至 ctx 结束或队列关闭；ctx 同时按 SubmitCtx 语义约束任务开始前的取消。
+// 在目标 Runtime 自身的 goroutine 中调用且队列已满时不阻塞，Future 以 ErrTaskQueueFull 与 runtime.ErrRuntimeSelfWait 失败。
+func SubmitWait(provider corectx.ConcurrentContextProvider, ctx context.Context, fun generic.FuncVar1[runtime.Context, any, async.Result], args ...any) async.Future {
+	return runtime.Concurrent(provider).SubmitWait(ctx, fun, args...)
+}
+
+// SubmitVoidWait 将无业务返回值函数投递到 provider 所属 Runtime；等待语义同 SubmitWait。
+func SubmitVoidWait(provider corectx.ConcurrentContextProvider, ctx context.Context, fun generic.ActionVar1[runtime.Context, any], args ...any) async.Future {
+	return runtime.Concurrent(provider).SubmitVoidWait(ctx, fun, args...)
+}
+
+// PostWait 将无返回值函数投递到 provider 所属 Runtime，有界队列已满时阻塞等待容量。
+// 返回 ctx.Err、队列关闭等入队错误；在目标 Runtime 自身的 goroutine 中调用且队列已满时不阻塞，
+// 返回 ErrTaskQueueFull 与 runtime.ErrRuntimeSelfWait。
+func PostWait(provider corectx.ConcurrentContextProvider, ctx context.Context, fun generic.ActionVar1[runtime.Context, any], args ...any) error {
+	return runtime.Concurrent(provider).PostWait(ctx, fun, args...)
+}
+
+// PostKeyed 将无返回值函数按 key 投递到 provider 所属 Runtime，不创建 Future。
+// 相同 key 的任务仍在排队时，新投递只替换其回调与参数，不新增队列项；key 为 nil 或不可比较时返回 ErrArgs。
+func PostKeyed(provider corectx.ConcurrentContextProvider, key any, fun generic.ActionVar1[runtime.Context, any], args ...any) error {
+	return runtime.Concurrent(provider).PostKeyed(key, fun, args...)
+}
+
+// SubmitCancelable 将有返回值函数投递到 provider 所属 Runtime，并返回可撤回的任务句柄。
+// 任务开始执行前撤回时，Future 以 ErrTaskCanceled 完成。
+func SubmitCancelable(provider corectx.ConcurrentContextProvider, fun generic.FuncVar1[runtime.Context, any, async.Result], args ...any) runtime.TaskHandle {
+	return runtime.Concurrent(provider).SubmitCancelable(fun, args...)
+}
+
+// SubmitVoidCancelable 将无业务返回值函数投递到 provider 所属 Runtime，并返回可撤回的任务句柄。
+func SubmitVoidCancelable(provider corectx.ConcurrentContextProvider, fun generic.ActionVar1[runtime.Context, any], args ...any) runtime.TaskHandle {
+	return runtime.Concurrent(provider).SubmitVoidCancelable(fun, args...)
+}
+
+// PostCancelable 将无返回值函数投递到 provider 所属 Runtime，并返回可撤回的任务句柄。
+func PostCancelable(provider corectx.ConcurrentContextProvider, fun generic.ActionVar1[runtime.Context, any], args ...any) (runtime.TaskHandle, error) {
+	return runtime.Concurrent(provider).PostCancelable(fun, args...)
+}
+
+// PostAfter 在 dur 后将无返回值函数投递到 provider 所属 Runtime 执行；Manual 模式下按帧换算。
+func PostAfter(provider corectx.ConcurrentContextProvider, dur time.Duration, fun generic.ActionVar1[runtime.Context, any], args ...any) error {
+	return runtime.Concurrent(provider).PostAfter(dur, fun, args...)
+}
+
+// PostAtFrame 在 provider 所属 Runtime 的已完成帧数达到 frame 后执行无返回值函数。
+func PostAtFrame(provider corectx.ConcurrentContextProvider, frame int64, fun generic.ActionVar1[runtime.Context, any], args ...any) error {
+	return runtime.Concurrent(provider).PostAtFrame(frame, fun, args...)
+}
+
+// PostAfterFrames 在 provider 所属 Runtime 再完成 frames 帧后执行无返回值函数。
+func PostAfterFrames(provider corectx.ConcurrentContextProvider, frames int64, fun generic.ActionVar1[runtime.Context, any], args ...any) error {
+	return runtime.Concurrent(provider).PostAfterFrames(frames, fun, args...)
+}
+
+// SubmitEntity 将有返回值函数投递到实体所属 Runtime，以存活的实体调用 fun。
+// 执行时实体已离开 Alive 状态返回 runtime.ErrEntityNotAlive，ID 已被复用返回 runtime.ErrEntityReplaced。
+func SubmitEntity(entity ec.ConcurrentEntity, fun generic.FuncVar1[ec.Entity, any, async.Result], args ...any) async.Future {
+	return runtime.SubmitEntity(entity, fun, args...)
+}
+
+// SubmitEntityDelegate 是 SubmitEntity 的 Delegate 版本。
+func SubmitEntityDelegate(entity ec.ConcurrentEntity, fun generic.DelegateVar1[ec.Entity, any, async.Result], args ...any) async.Future {
+	return runtime.SubmitEntityDelegate(entity, fun, args...)
+}
+
+// SubmitEntityVoid 将无业务返回值函数投递到实体所属 Runtime；实体检查同 SubmitEntity。
+func SubmitEntityVoid(entity ec.ConcurrentEntity, fun generic.ActionVar1[ec.Entity, any], args ...any) async.Future {
+	return runtime.SubmitEntityVoid(entity, fun, args...)
+}
+
+// SubmitEntityDelegateVoid 是 SubmitEntityVoid 的 DelegateVoid 版本。
+func SubmitEntityDelegateVoid(entity ec.ConcurrentEntity, fun generic.DelegateVoidVar1[ec.Entity, any], args ...any) async.Future {
+	return runtime.SubmitEntityDelegateVoid(entity, fun, args...)
+}
+
+// PostEntity 将无返回值函数投递到实体所属 Runtime，不创建 Future；执行时实体检查失败则丢弃该任务。
+func PostEntity(entity ec.ConcurrentEntity, fun generic.ActionVar1[ec.Entity, any], args ...any) error {
+	return runtime.PostEntity(entity, fun, args...)
+}
+
+// PostEntityDelegate 是 PostEntity 的 DelegateVoid 版本。
+func PostEntityDelegate(entity ec.ConcurrentEntity, fun generic.DelegateVoidVar1[ec.Entity, any], args ...any) error {
+	return runtime.PostEntityDelegate(entity, fun, args...)
+}
+
+// SubmitComponent 将有返回值函数投递到组件所属 Runtime，以存活的组件调用 fun。
+// 执行时组件已离开 Alive 状态返回 runtime.ErrComponentNotAlive；所属实体的检查同 SubmitEntity。
+func SubmitComponent(comp ec.ConcurrentComponent, fun generic.FuncVar1[ec.Component, any, async.Result], args ...any) async.Future {
+	return runtime.SubmitComponent(comp, fun, args...)
+}
+
+// SubmitComponentDelegate 是 SubmitComponent 的 Delegate 版本。
+func SubmitComponentDelegate(comp ec.ConcurrentComponent, fun generic.DelegateVar1[ec.Component, any, async.Result], args ...any) async.Future {
+	return runtime.SubmitComponentDelegate(comp, fun, args...)
+}
+
+// SubmitComponentVoid 将无业务返回值函数投递到组件所属 Runtime；组件检查同 SubmitComponent。
+func SubmitComponentVoid(comp ec.ConcurrentComponent, fun generic.ActionVar1[ec.Component, any], args ...any) async.Future {
+	return runtime.SubmitComponentVoid(comp, fun, args...)
+}
+
+// SubmitComponentDelegateVoid 是 SubmitComponentVoid 的 DelegateVoid 版本。
+func SubmitComponentDelegateVoid(comp ec.ConcurrentComponent, fun generic.DelegateVoidVar1[ec.Component, any], args ...any) async.Future {
+	return runtime.SubmitComponentDelegateVoid(comp, fun, args...)
+}
+
+// PostComponent 将无返回值函数投递到组件所属 Runtime，不创建 Future；执行时组件检查失败则丢弃该任务。
+func PostComponent(comp ec.ConcurrentComponent, fun generic.ActionVar1[ec.Component, any], args ...any) error {
+	return runtime.PostComponent(comp, fun, args...)
+}
+
+// PostComponentDelegate 是 PostComponent 的 DelegateVoid 版本。
+func PostComponentDelegate(comp ec.ConcurrentComponent, fun generic.DelegateVoidVar1[ec.Component, any], args ...any) error {
+	return runtime.PostComponentDelegate(comp, fun, args...)
+}
+
 // Spawn 在 provider 的生命周期 Scope 中启动后台 goroutine。
 // fun 不得直接访问 Runtime 局部状态。
 func Spawn(provider corectx.AsyncScopeProvider, fun generic.FuncVar1[context.Context, any, async.Result], args ...any) async.Future {
@@ -83,6 +229,7 @@ func SpawnVoid(provider corectx.AsyncScopeProvider, fun generic.ActionVar1[conte
 }
 
 // After 在 dur 后以当前时间完成 Future；ctx 取消时以 ctx.Err 完成。
+// 计时使用 clock.FromContext(ctx) 返回的时钟，传入运行时上下文时使用运行时的时钟。
 func After(ctx context.Context, dur time.Duration) async.Future {
 	if ctx == nil {
 		ctx = context.Background()
@@ -90,9 +237,10 @@ func After(ctx context.Context, dur time.Duration) async.Future {
 	if dur < 0 {
 		dur = 0
 	}
+	c := clock.FromContext(ctx)
 	promise, future := async.NewPromise()
-	timer := time.AfterFunc(dur, func() {
-		promise.Resolve(async.NewResult(time.Now(), nil))
+	timer := c.AfterFunc(dur, func() {
+		promise.Resolve(async.NewResult(c.Now(), nil))
 	})
 	stopContext := context.AfterFunc(ctx, func() {
 		promise.Resolve(async.NewResult(nil, ctx.Err()))
@@ -106,10 +254,10 @@ func After(ctx context.Context, dur time.Duration) async.Future {
 
 // At 在指定时间以当前时间完成 Future；ctx 取消时以 ctx.Err 完成。
 func At(ctx context.Context, at time.Time) async.Future {
-	return After(ctx, time.Until(at))
+	return After(ctx, clock.FromContext(ctx).Until(at))
 }
 
-// Every 按 dur 周期持续产出当前时间，直到 ctx 取消。
+// Every 按 dur 周期持续产出当前时间，直到 ctx 取消；时钟的选择与 After 相同。
 func Every(ctx context.Context, dur time.Duration) async.Stream {
 	if ctx == nil {
 		ctx = context.Background()
@@ -117,14 +265,15 @@ func Every(ctx context.Context, dur time.Duration) async.Stream {
 	if dur <= 0 {
 		exception.Panicf("%w: %w: duration must be positive", ErrCore, ErrArgs)
 	}
+	c := clock.FromContext(ctx)
 	emitter, stream := async.NewStream()
 	go func() {
 		defer emitter.Close()
-		ticker := time.NewTicker(dur)
+		ticker := c.NewTicker(dur)
 		defer ticker.Stop()
 		for {
 			select {
-			case now := <-ticker.C:
+			case now := <-ticker.C():
 				if !emitter.Emit(ctx, async.NewResult(now, nil)) {
 					return
 				}
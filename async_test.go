@@ -0,0 +1,151 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"errors"
+	"testing"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/tiny/ec"
+	"git.golaxy.org/tiny/ec/pt"
+	"git.golaxy.org/tiny/runtime"
+)
+
+type callerTestComp struct {
+	ec.ComponentBehavior
+}
+
+// newCallerTestEntity 创建拥有可删除组件 comp 的实体。
+func newCallerTestEntity(t *testing.T, rt *RuntimeBehavior) ec.Entity {
+	t.Helper()
+	ret := waitTestFuture(t, rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		return async.NewResult(BuildEntity(ctx, "unit").New())
+	}))
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+	return ret.Value.(ec.Entity)
+}
+
+func startCallerTestRuntime(t *testing.T) *RuntimeBehavior {
+	t.Helper()
+	rtCtx := runtime.NewContext()
+	BuildEntityPT(rtCtx, "unit").AddComponent(pt.NewComponentDescriptor(&callerTestComp{}).SetName("comp").SetRemovable(true)).Declare()
+	return startTestRuntime(t, rtCtx)
+}
+
+func TestSubmitComponentDelegate(t *testing.T) {
+	rt := startCallerTestRuntime(t)
+	comp := newCallerTestEntity(t, rt).GetComponent("comp")
+
+	ret := waitTestFuture(t, SubmitComponentDelegate(comp, generic.DelegateVar1[ec.Component, any, async.Result]{
+		func(c ec.Component, _ ...any) async.Result {
+			return async.NewResult(c.Name(), nil)
+		},
+	}))
+	if ret.Error != nil || ret.Value != "comp" {
+		t.Fatalf("submit: got %v, %v, want comp", ret.Value, ret.Error)
+	}
+
+	var called int
+	fun := generic.DelegateVoidVar1[ec.Component, any]{
+		func(ec.Component, ...any) { called++ },
+	}
+	if ret := waitTestFuture(t, SubmitComponentDelegateVoid(comp, fun)); ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+	if err := PostComponentDelegate(comp, fun); err != nil {
+		t.Fatal(err)
+	}
+	// 任务按投递顺序执行，等待其后的任务完成即可确认 Post 已执行
+	waitTestFuture(t, rt.Submit(func(runtime.Context, ...any) async.Result { return async.NewResult(nil, nil) }))
+	if called != 2 {
+		t.Fatalf("delegate calls: got %d, want 2", called)
+	}
+}
+
+func TestSubmitEntityNotAlive(t *testing.T) {
+	rt := startCallerTestRuntime(t)
+	entity := newCallerTestEntity(t, rt)
+
+	waitTestFuture(t, rt.Submit(func(runtime.Context, ...any) async.Result {
+		entity.Destroy()
+		return async.NewResult(nil, nil)
+	}))
+
+	ret := waitTestFuture(t, SubmitEntityVoid(entity, func(ec.Entity, ...any) {
+		t.Error("called with destroyed entity")
+	}))
+	if !errors.Is(ret.Error, runtime.ErrEntityNotAlive) {
+		t.Fatalf("submit: got %v, want %v", ret.Error, runtime.ErrEntityNotAlive)
+	}
+}
+
+func TestSubmitEntityReplaced(t *testing.T) {
+	rt := startCallerTestRuntime(t)
+	entity := newCallerTestEntity(t, rt)
+	comp := entity.GetComponent("comp")
+
+	// 移除后以相同 ID 创建新实体
+	waitTestFuture(t, rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		ctx.EntityManager().RemoveEntity(entity.ID())
+		return async.NewResult(nil, nil)
+	}))
+	ret := waitTestFuture(t, rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		return async.NewResult(BuildEntity(ctx, "unit").SetID(entity.ID()).New())
+	}))
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+
+	ret = waitTestFuture(t, SubmitEntityVoid(entity, func(ec.Entity, ...any) {
+		t.Error("called with replaced entity")
+	}))
+	if !errors.Is(ret.Error, runtime.ErrEntityReplaced) {
+		t.Fatalf("submit: got %v, want %v", ret.Error, runtime.ErrEntityReplaced)
+	}
+
+	// 组件所属实体被替换时同样拒绝
+	ret = waitTestFuture(t, SubmitComponentVoid(comp, func(ec.Component, ...any) {
+		t.Error("called with component of replaced entity")
+	}))
+	if !errors.Is(ret.Error, runtime.ErrEntityReplaced) {
+		t.Fatalf("submit component: got %v, want %v", ret.Error, runtime.ErrEntityReplaced)
+	}
+}
+
+func TestSubmitComponentNotAlive(t *testing.T) {
+	rt := startCallerTestRuntime(t)
+	comp := newCallerTestEntity(t, rt).GetComponent("comp")
+
+	waitTestFuture(t, rt.Submit(func(runtime.Context, ...any) async.Result {
+		comp.Destroy()
+		return async.NewResult(nil, nil)
+	}))
+
+	ret := waitTestFuture(t, SubmitComponentDelegateVoid(comp, generic.DelegateVoidVar1[ec.Component, any]{
+		func(ec.Component, ...any) { t.Error("called with destroyed component") },
+	}))
+	if !errors.Is(ret.Error, runtime.ErrComponentNotAlive) {
+		t.Fatalf("submit: got %v, want %v", ret.Error, runtime.ErrComponentNotAlive)
+	}
+}
@@ -78,6 +78,9 @@ type iComponent interface {
 	setAttachedHandle(idx int, ver int64)
 	managedRuntimeUpdateHandle(updateHandle event.Handle)
 	managedRuntimeLateUpdateHandle(lateUpdateHandle event.Handle)
+	managedRuntimeFixedUpdateHandle(fixedUpdateHandle event.Handle)
+	managedRuntimeLockstepInputHandle(lockstepInputHandle event.Handle)
+	managedRuntimePhaseHandles(phaseHandles []event.Handle)
 	managedUnbindRuntimeHandles()
 }
 
@@ -103,7 +106,8 @@ type ComponentBehavior struct {
 	attachedIndex         int
 	attachedVersion       int64
 	managedHandles        event.ManagedHandles
-	managedRuntimeHandles [2]event.Handle
+	managedRuntimeHandles [4]event.Handle
+	managedPhaseHandles   []event.Handle
 
 	componentEventTab componentEventTab
 }
@@ -302,6 +306,27 @@ func (comp *ComponentBehavior) managedRuntimeLateUpdateHandle(lateUpdateHandle e
 	comp.managedRuntimeHandles[1] = lateUpdateHandle
 }
 
+func (comp *ComponentBehavior) managedRuntimeFixedUpdateHandle(fixedUpdateHandle event.Handle) {
+	if comp.managedRuntimeHandles[2] != fixedUpdateHandle {
+		comp.managedRuntimeHandles[2].Unbind()
+	}
+	comp.managedRuntimeHandles[2] = fixedUpdateHandle
+}
+
+func (comp *ComponentBehavior) managedRuntimeLockstepInputHandle(lockstepInputHandle event.Handle) {
+	if comp.managedRuntimeHandles[3] != lockstepInputHandle {
+		comp.managedRuntimeHandles[3].Unbind()
+	}
+	comp.managedRuntimeHandles[3] = lockstepInputHandle
+}
+
+func (comp *ComponentBehavior) managedRuntimePhaseHandles(phaseHandles []event.Handle) {
+	event.UnbindHandles(comp.managedPhaseHandles)
+	comp.managedPhaseHandles = phaseHandles
+}
+
 func (comp *ComponentBehavior) managedUnbindRuntimeHandles() {
 	event.UnbindHandles(comp.managedRuntimeHandles[:])
+	event.UnbindHandles(comp.managedPhaseHandles)
+	comp.managedPhaseHandles = nil
 }
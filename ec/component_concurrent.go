@@ -50,6 +50,7 @@ type ConcurrentComponent interface {
 
 type iConcurrentComponent interface {
 	getInstance() Component
+	loadedAsyncScope() *async.Scope
 }
 
 // componentAsyncScopeState 发布后不可变；nil 指针表示尚未创建且仍可用。
@@ -65,6 +66,14 @@ func (comp *ComponentBehavior) ConcurrentContextCache() iface.Cache {
 	return comp.entity.ConcurrentContextCache()
 }
 
+// loadedAsyncScope 返回已创建的 AsyncScope，尚未创建时返回 nil，不会触发懒创建。
+func (comp *ComponentBehavior) loadedAsyncScope() *async.Scope {
+	if state := comp.asyncScope.Load(); state != nil {
+		return state.scope
+	}
+	return nil
+}
+
 // AsyncScope 返回绑定组件生命周期的后台任务作用域，并在首次访问时懒创建。
 // Scope 在组件从 Entity 移除或随 Entity 销毁时关闭；SetEnabled(false) 不会关闭它。
 // 所属 Entity 尚未绑定 Runtime Context 时返回 nil；组件已关闭后首次访问会返回已关闭的 Scope。
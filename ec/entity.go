@@ -25,6 +25,7 @@ import (
 
 	"git.golaxy.org/core/event"
 	"git.golaxy.org/core/utils/corectx"
+	"git.golaxy.org/core/utils/exception"
 	"git.golaxy.org/core/utils/generic"
 	"git.golaxy.org/core/utils/iface"
 	"git.golaxy.org/core/utils/meta"
@@ -77,6 +78,10 @@ type Entity interface {
 	Meta() meta.Meta
 	// Managed 返回随实体销毁自动解绑的事件句柄集合。
 	Managed() *event.ManagedHandles
+	// UpdateInterval 返回实体及其组件每帧更新的间隔帧数。
+	UpdateInterval() int64
+	// SetUpdateInterval 设置实体及其组件每帧更新的间隔帧数，可在运行期间调整；n 必须大于 0。
+	SetUpdateInterval(n int64)
 	// Destroy 请求所属 Runtime 销毁实体；重复请求会被忽略。
 	Destroy()
 
@@ -95,6 +100,9 @@ type iEntity interface {
 	setEnteredHandle(idx int, ver int64)
 	managedRuntimeUpdateHandle(updateHandle event.Handle)
 	managedRuntimeLateUpdateHandle(lateUpdateHandle event.Handle)
+	managedRuntimeFixedUpdateHandle(fixedUpdateHandle event.Handle)
+	managedRuntimeLockstepInputHandle(lockstepInputHandle event.Handle)
+	managedRuntimePhaseHandles(phaseHandles []event.Handle)
 	managedUnbindRuntimeHandles()
 }
 
@@ -118,7 +126,8 @@ type EntityBehavior struct {
 	enteredIndex          int
 	enteredVersion        int64
 	managedHandles        event.ManagedHandles
-	managedRuntimeHandles [2]event.Handle
+	managedRuntimeHandles [4]event.Handle
+	managedPhaseHandles   []event.Handle
 
 	entityEventTab                 entityEventTab
 	entityComponentManagerEventTab entityComponentManagerEventTab
@@ -162,6 +171,24 @@ func (entity *EntityBehavior) Managed() *event.ManagedHandles {
 	return &entity.managedHandles
 }
 
+// UpdateInterval 返回实体及其组件每帧更新的间隔帧数。
+func (entity *EntityBehavior) UpdateInterval() int64 {
+	return entity.options.UpdateInterval
+}
+
+// SetUpdateInterval 设置实体及其组件每帧更新的间隔帧数，可在运行期间调整；n 必须大于 0。
+// 间隔大于 1 时，Update、LateUpdate 与自定义帧阶段按间隔执行，FixedUpdate 不受影响。
+func (entity *EntityBehavior) SetUpdateInterval(n int64) {
+	if n <= 0 {
+		exception.Panicf("%w: %w: n must be greater than 0", ErrEC, exception.ErrArgs)
+	}
+	if entity.options.UpdateInterval == n {
+		return
+	}
+	entity.options.UpdateInterval = n
+	_EmitEventEntityUpdateIntervalChanged(entity, entity.getInstance(), n)
+}
+
 // Destroy 请求所属 Runtime 销毁实体；实体离开活动阶段后调用无效。
 func (entity *EntityBehavior) Destroy() {
 	entity.reentrancyGuard.Call(entityReentrancyGuard_Destroy, func() {
@@ -177,6 +204,11 @@ func (entity *EntityBehavior) EventEntityDestroy() event.IEvent {
 	return entity.entityEventTab.EventEntityDestroy()
 }
 
+// EventEntityUpdateIntervalChanged 返回实体更新间隔改变事件。
+func (entity *EntityBehavior) EventEntityUpdateIntervalChanged() event.IEvent {
+	return entity.entityEventTab.EventEntityUpdateIntervalChanged()
+}
+
 // CurrentContextCache 返回实体所属 Runtime 的当前上下文接口缓存。
 func (entity *EntityBehavior) CurrentContextCache() iface.Cache {
 	return entity.runtimeCtx.CurrentContextCache()
@@ -257,6 +289,27 @@ func (entity *EntityBehavior) managedRuntimeLateUpdateHandle(lateUpdateHandle ev
 	entity.managedRuntimeHandles[1] = lateUpdateHandle
 }
 
+func (entity *EntityBehavior) managedRuntimeFixedUpdateHandle(fixedUpdateHandle event.Handle) {
+	if entity.managedRuntimeHandles[2] != fixedUpdateHandle {
+		entity.managedRuntimeHandles[2].Unbind()
+	}
+	entity.managedRuntimeHandles[2] = fixedUpdateHandle
+}
+
+func (entity *EntityBehavior) managedRuntimeLockstepInputHandle(lockstepInputHandle event.Handle) {
+	if entity.managedRuntimeHandles[3] != lockstepInputHandle {
+		entity.managedRuntimeHandles[3].Unbind()
+	}
+	entity.managedRuntimeHandles[3] = lockstepInputHandle
+}
+
+func (entity *EntityBehavior) managedRuntimePhaseHandles(phaseHandles []event.Handle) {
+	event.UnbindHandles(entity.managedPhaseHandles)
+	entity.managedPhaseHandles = phaseHandles
+}
+
 func (entity *EntityBehavior) managedUnbindRuntimeHandles() {
 	event.UnbindHandles(entity.managedRuntimeHandles[:])
+	event.UnbindHandles(entity.managedPhaseHandles)
+	entity.managedPhaseHandles = nil
 }
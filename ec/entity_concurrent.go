@@ -57,6 +57,7 @@ type runtimeContext interface {
 type iConcurrentEntity interface {
 	getInstance() Entity
 	setContext(rtCtx runtimeContext)
+	loadedAsyncScope() *async.Scope
 }
 
 // entityAsyncScopeState 发布后不可变；nil 指针表示尚未创建且仍可用。
@@ -112,6 +113,14 @@ func (entity *EntityBehavior) String() string {
 	return entity.ID().String()
 }
 
+// loadedAsyncScope 返回已创建的 AsyncScope，尚未创建时返回 nil，不会触发懒创建。
+func (entity *EntityBehavior) loadedAsyncScope() *async.Scope {
+	if state := entity.asyncScope.Load(); state != nil {
+		return state.scope
+	}
+	return nil
+}
+
 func (entity *EntityBehavior) getInstance() Entity {
 	return entity.options.InstanceFace.Iface
 }
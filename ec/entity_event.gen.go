@@ -70,3 +70,49 @@ type EventEntityDestroyHandler func(entity Entity)
 func (h EventEntityDestroyHandler) OnEntityDestroy(entity Entity) {
 	h(entity)
 }
+
+type iAutoEventEntityUpdateIntervalChanged interface {
+	EventEntityUpdateIntervalChanged() event.IEvent
+}
+
+func BindEventEntityUpdateIntervalChanged(auto iAutoEventEntityUpdateIntervalChanged, subscriber EventEntityUpdateIntervalChanged, priority ...int32) event.Handle {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventEntityUpdateIntervalChanged](auto.EventEntityUpdateIntervalChanged(), subscriber, priority...)
+}
+
+func _EmitEventEntityUpdateIntervalChanged(auto iAutoEventEntityUpdateIntervalChanged, entity Entity, interval int64) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityUpdateIntervalChanged()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventEntityUpdateIntervalChanged](subscriber).OnEntityUpdateIntervalChanged(entity, interval)
+		return true
+	})
+}
+
+func _EmitEventEntityUpdateIntervalChangedWithInterrupt(auto iAutoEventEntityUpdateIntervalChanged, interrupt func(entity Entity, interval int64) bool, entity Entity, interval int64) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityUpdateIntervalChanged()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(entity, interval) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventEntityUpdateIntervalChanged](subscriber).OnEntityUpdateIntervalChanged(entity, interval)
+		return true
+	})
+}
+
+func HandleEventEntityUpdateIntervalChanged(fun func(entity Entity, interval int64)) EventEntityUpdateIntervalChangedHandler {
+	return EventEntityUpdateIntervalChangedHandler(fun)
+}
+
+type EventEntityUpdateIntervalChangedHandler func(entity Entity, interval int64)
+
+func (h EventEntityUpdateIntervalChangedHandler) OnEntityUpdateIntervalChanged(entity Entity, interval int64) {
+	h(entity, interval)
+}
@@ -27,3 +27,10 @@ package ec
 type EventEntityDestroy interface {
 	OnEntityDestroy(entity Entity)
 }
+
+// EventEntityUpdateIntervalChanged 在实体更新间隔改变后同步派发。
+// +event-gen:export_emit=0
+// +event-tab-gen:recursion=allow
+type EventEntityUpdateIntervalChanged interface {
+	OnEntityUpdateIntervalChanged(entity Entity, interval int64)
+}
@@ -27,14 +27,16 @@ import (
 
 type IEntityEventTab interface {
 	EventEntityDestroy() event.IEvent
+	EventEntityUpdateIntervalChanged() event.IEvent
 }
 
 var (
-	_entityEventTabID    = event.DeclareEventTabIDT[entityEventTab]()
-	EventEntityDestroyID = event.DeclareEventIDT[entityEventTab](0)
+	_entityEventTabID                  = event.DeclareEventTabIDT[entityEventTab]()
+	EventEntityDestroyID               = event.DeclareEventIDT[entityEventTab](0)
+	EventEntityUpdateIntervalChangedID = event.DeclareEventIDT[entityEventTab](1)
 )
 
-type entityEventTab [1]event.Event
+type entityEventTab [2]event.Event
 
 func (eventTab *entityEventTab) SetPanicHandling(autoRecover bool, reportError chan error) {
 	for i := range eventTab {
@@ -44,6 +46,7 @@ func (eventTab *entityEventTab) SetPanicHandling(autoRecover bool, reportError c
 
 func (eventTab *entityEventTab) SetRecursion(recursion event.EventRecursion) {
 	eventTab[0].SetRecursion(event.EventRecursion_Allow)
+	eventTab[1].SetRecursion(event.EventRecursion_Allow)
 }
 
 func (eventTab *entityEventTab) SetEnabled(b bool) {
@@ -70,6 +73,8 @@ func (eventTab *entityEventTab) Event(id uint64) event.IEvent {
 	switch pos {
 	case 0:
 		eventTab[0].SetRecursion(event.EventRecursion_Allow)
+	case 1:
+		eventTab[1].SetRecursion(event.EventRecursion_Allow)
 	}
 	return &eventTab[pos]
 }
@@ -78,3 +83,8 @@ func (eventTab *entityEventTab) EventEntityDestroy() event.IEvent {
 	eventTab.SetRecursion(event.EventRecursion_Allow)
 	return &eventTab[0]
 }
+
+func (eventTab *entityEventTab) EventEntityUpdateIntervalChanged() event.IEvent {
+	eventTab.SetRecursion(event.EventRecursion_Allow)
+	return &eventTab[1]
+}
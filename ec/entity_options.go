@@ -20,6 +20,7 @@
 package ec
 
 import (
+	"git.golaxy.org/core/utils/exception"
 	"git.golaxy.org/core/utils/iface"
 	"git.golaxy.org/core/utils/meta"
 	"git.golaxy.org/core/utils/option"
@@ -33,6 +34,7 @@ type EntityOptions struct {
 	ComponentAwakeOnFirstTouch bool               // ComponentAwakeOnFirstTouch 指示正常激活期间被访问的组件是否优先执行 Awake。
 	ComponentUniqueID          bool               // ComponentUniqueID 指示是否为每个组件分配唯一 ID。
 	Meta                       meta.Meta          // Meta 是随实体携带的元数据。
+	UpdateInterval             int64              // UpdateInterval 是实体及其组件每帧更新的间隔帧数；1 表示每帧更新。
 }
 
 // With 提供实体选项构造器。
@@ -48,6 +50,7 @@ func (_EntityOption) Default() option.Setting[EntityOptions] {
 		With.ComponentAwakeOnFirstTouch(false).Apply(options)
 		With.ComponentUniqueID(false).Apply(options)
 		With.Meta(nil).Apply(options)
+		With.UpdateInterval(1).Apply(options)
 	}
 }
 
@@ -85,3 +88,13 @@ func (_EntityOption) Meta(m meta.Meta) option.Setting[EntityOptions] {
 		options.Meta = m
 	}
 }
+
+// UpdateInterval 设置实体及其组件每帧更新的间隔帧数；n 必须大于 0。
+func (_EntityOption) UpdateInterval(n int64) option.Setting[EntityOptions] {
+	return func(options *EntityOptions) {
+		if n <= 0 {
+			exception.Panicf("%w: %w: UpdateInterval must be greater than 0", ErrEC, exception.ErrArgs)
+		}
+		options.UpdateInterval = n
+	}
+}
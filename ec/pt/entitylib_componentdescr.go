@@ -20,10 +20,15 @@
 package pt
 
 import (
+	"slices"
+
 	"git.golaxy.org/core/utils/exception"
 	"git.golaxy.org/core/utils/meta"
 )
 
+// MetaFramePhases 是内建组件元数据中记录订阅帧阶段的键，值为 []string。
+const MetaFramePhases = "frame_phases"
+
 // NewComponentDescriptor 创建用于实体原型声明的组件描述；instance 为 nil 时 panic。
 func NewComponentDescriptor(instance any) *ComponentDescriptor {
 	if instance == nil {
@@ -84,3 +89,9 @@ func (descr *ComponentDescriptor) AssignMeta(m meta.Meta) *ComponentDescriptor {
 	descr.Meta = m
 	return descr
 }
+
+// SetFramePhases 在元数据中记录组件订阅的自定义帧阶段并返回 descr。
+func (descr *ComponentDescriptor) SetFramePhases(phases ...string) *ComponentDescriptor {
+	descr.Meta.Add(MetaFramePhases, slices.Clone(phases))
+	return descr
+}
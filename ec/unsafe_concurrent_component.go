@@ -19,6 +19,8 @@
 
 package ec
 
+import "git.golaxy.org/core/utils/async"
+
 // UnsafeConcurrentComponent 暴露 ConcurrentComponent 的框架内部能力。
 //
 // Deprecated: 仅供框架内部使用。
@@ -34,3 +36,8 @@ type _UnsafeConcurrentComponent struct {
 func (u _UnsafeConcurrentComponent) Instance() Component {
 	return u.getInstance()
 }
+
+// LoadedAsyncScope 返回已创建的 AsyncScope；尚未创建时返回 nil，不会触发懒创建。
+func (u _UnsafeConcurrentComponent) LoadedAsyncScope() *async.Scope {
+	return u.loadedAsyncScope()
+}
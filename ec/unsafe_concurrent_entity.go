@@ -19,6 +19,8 @@
 
 package ec
 
+import "git.golaxy.org/core/utils/async"
+
 // UnsafeConcurrentEntity 暴露 ConcurrentEntity 的框架内部能力。
 //
 // Deprecated: 仅供框架内部使用。
@@ -36,3 +38,8 @@ type _UnsafeConcurrentEntity struct {
 func (u _UnsafeConcurrentEntity) Instance() Entity {
 	return u.getInstance()
 }
+
+// LoadedAsyncScope 返回已创建的 AsyncScope；尚未创建时返回 nil，不会触发懒创建。
+func (u _UnsafeConcurrentEntity) LoadedAsyncScope() *async.Scope {
+	return u.loadedAsyncScope()
+}
@@ -100,6 +100,21 @@ func (u _UnsafeEntity) ManagedRuntimeLateUpdateHandle(lateUpdateHandle event.Han
 	u.managedRuntimeLateUpdateHandle(lateUpdateHandle)
 }
 
+// ManagedRuntimeFixedUpdateHandle 替换并托管 Runtime 固定步长更新事件句柄。
+func (u _UnsafeEntity) ManagedRuntimeFixedUpdateHandle(fixedUpdateHandle event.Handle) {
+	u.managedRuntimeFixedUpdateHandle(fixedUpdateHandle)
+}
+
+// ManagedRuntimeLockstepInputHandle 替换并托管 Runtime 锁步输入事件句柄。
+func (u _UnsafeEntity) ManagedRuntimeLockstepInputHandle(lockstepInputHandle event.Handle) {
+	u.managedRuntimeLockstepInputHandle(lockstepInputHandle)
+}
+
+// ManagedRuntimePhaseHandles 替换并托管 Runtime 自定义帧阶段事件句柄。
+func (u _UnsafeEntity) ManagedRuntimePhaseHandles(phaseHandles []event.Handle) {
+	u.managedRuntimePhaseHandles(phaseHandles)
+}
+
 // ManagedUnbindRuntimeHandles 解绑全部托管的 Runtime 更新事件句柄。
 func (u _UnsafeEntity) ManagedUnbindRuntimeHandles() {
 	u.managedUnbindRuntimeHandles()
@@ -0,0 +1,117 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"fmt"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/core/utils/uid"
+	"git.golaxy.org/tiny/ec"
+	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/id"
+)
+
+var (
+	ErrRuntimeNotFound = fmt.Errorf("%w: runtime not found", ErrRuntime) // 注册表中不存在目标运行时。
+)
+
+// EntityAddress 跨运行时的实体地址，由运行时持久化 ID 与运行时内的实体 ID 组成。
+type EntityAddress struct {
+	RuntimeID uid.ID // 运行时上下文的持久化 ID。
+	EntityID  id.ID  // 运行时内的实体 ID。
+}
+
+// AddressOf 返回实体的地址，实体必须已加入运行时。
+func AddressOf(entity ec.ConcurrentEntity) EntityAddress {
+	return EntityAddress{
+		RuntimeID: runtime.Concurrent(entity).ID(),
+		EntityID:  entity.ID(),
+	}
+}
+
+// String 返回 "运行时ID/实体ID" 形式的文本。
+func (addr EntityAddress) String() string {
+	return fmt.Sprintf("%s/%s", addr.RuntimeID, addr.EntityID)
+}
+
+// CallEntity 使用 DefaultRegistry 解析地址，在目标运行时中对实体执行 fun。
+func CallEntity(addr EntityAddress, fun generic.FuncVar1[ec.Entity, any, async.Result], args ...any) async.Future {
+	return DefaultRegistry.CallEntity(addr, fun, args...)
+}
+
+// SendEntity 使用 DefaultRegistry 解析地址，向目标运行时投递对实体执行的 fun。
+func SendEntity(addr EntityAddress, fun generic.ActionVar1[ec.Entity, any], args ...any) error {
+	return DefaultRegistry.SendEntity(addr, fun, args...)
+}
+
+// CallEntity 解析地址所在的运行时，在其 Runtime goroutine 中查找实体并执行 fun。
+// 运行时未注册时返回 ErrRuntimeNotFound；执行时实体不存在或已离开 Alive 状态时返回 runtime.ErrEntityNotAlive。
+func (r *Registry) CallEntity(addr EntityAddress, fun generic.FuncVar1[ec.Entity, any, async.Result], args ...any) async.Future {
+	if fun == nil {
+		return async.Rejected(fmt.Errorf("%w: %w: fun is nil", ErrRuntime, ErrArgs))
+	}
+
+	rt, ok := r.Get(addr.RuntimeID)
+	if !ok {
+		return async.Rejected(fmt.Errorf("%w: %q", ErrRuntimeNotFound, addr.RuntimeID))
+	}
+
+	return rt.Submit(func(ctx runtime.Context, args ...any) async.Result {
+		entity, err := lookupEntity(ctx, addr)
+		if err != nil {
+			return async.NewResult(nil, err)
+		}
+		return fun.UnsafeCall(entity, args...)
+	}, args...)
+}
+
+// SendEntity 解析地址所在的运行时并投递对实体执行的 fun，不等待执行结果。
+// 运行时未注册时返回 ErrRuntimeNotFound；执行时实体不存在或已离开 Alive 状态则丢弃该任务。
+func (r *Registry) SendEntity(addr EntityAddress, fun generic.ActionVar1[ec.Entity, any], args ...any) error {
+	if fun == nil {
+		return fmt.Errorf("%w: %w: fun is nil", ErrRuntime, ErrArgs)
+	}
+
+	rt, ok := r.Get(addr.RuntimeID)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrRuntimeNotFound, addr.RuntimeID)
+	}
+
+	return rt.Post(func(ctx runtime.Context, args ...any) {
+		entity, err := lookupEntity(ctx, addr)
+		if err != nil {
+			return
+		}
+		fun.UnsafeCall(entity, args...)
+	}, args...)
+}
+
+func lookupEntity(ctx runtime.Context, addr EntityAddress) (ec.Entity, error) {
+	entity, ok := ctx.EntityManager().GetEntity(addr.EntityID)
+	if !ok {
+		return nil, fmt.Errorf("%w: entity %q not found", runtime.ErrEntityNotAlive, addr)
+	}
+	if entity.State() > ec.EntityState_Alive {
+		return nil, fmt.Errorf("%w: entity %q is in state %q", runtime.ErrEntityNotAlive, addr, entity.State())
+	}
+	return entity, nil
+}
@@ -81,6 +81,12 @@ func (c *EntityCreator) SetComponentUniqueID(b bool) *EntityCreator {
 	return c
 }
 
+// SetUpdateInterval 设置实体及其组件每帧更新的间隔帧数；n 必须大于 0。
+func (c *EntityCreator) SetUpdateInterval(n int64) *EntityCreator {
+	c.settings = append(c.settings, ec.With.UpdateInterval(n))
+	return c
+}
+
 // SetMeta 用 dict 替换待创建实体的元数据。
 func (c *EntityCreator) SetMeta(dict map[string]any) *EntityCreator {
 	if c.meta == nil {
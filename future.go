@@ -0,0 +1,134 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/core/utils/corectx"
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/tiny/runtime"
+)
+
+var (
+	ErrResultType = fmt.Errorf("%w: unexpected result type", ErrCore) // Future 结果值的类型与期望不符。
+)
+
+// FutureT 是结果值类型为 T 的 Future，结果值的类型在读取时检查，不符时返回 ErrResultType。
+type FutureT[T any] struct {
+	future async.Future
+	ctx    corectx.Context
+}
+
+// AsFutureT 将 future 包装为结果值类型为 T 的 FutureT。
+func AsFutureT[T any](future async.Future) FutureT[T] {
+	return FutureT[T]{future: future}
+}
+
+// asFutureT 将 future 包装为 FutureT，OnComplete 回调使用 ctx 的 panic 处理方式。
+func asFutureT[T any](ctx corectx.Context, future async.Future) FutureT[T] {
+	return FutureT[T]{future: future, ctx: ctx}
+}
+
+// Untyped 返回底层 Future，可用于 ContinueOn 等接收 async.Future 的接口。
+func (f FutureT[T]) Untyped() async.Future {
+	return f.future
+}
+
+// IsNil 返回底层 Future 是否为空。
+func (f FutureT[T]) IsNil() bool {
+	return f.future.IsNil()
+}
+
+// Wait 等待 Future 完成并返回 T 类型结果；ctx 结束时返回 ctx 的错误。
+func (f FutureT[T]) Wait(ctx context.Context) (T, error) {
+	return resultT[T](f.future.Wait(ctx))
+}
+
+// OnComplete 在 Future 完成后以 T 类型结果调用 fun。fun 中的 panic 按所属运行时的设置恢复并报告；
+// 通过 AsFutureT 创建、未关联运行时的 FutureT 恢复 panic 但不报告。
+func (f FutureT[T]) OnComplete(fun func(value T, err error)) {
+	if fun == nil {
+		exception.Panicf("%w: %w: fun is nil", ErrCore, ErrArgs)
+	}
+	autoRecover, reportError := true, chan error(nil)
+	if f.ctx != nil {
+		autoRecover, reportError = f.ctx.AutoRecover(), f.ctx.ReportError()
+	}
+	f.future.OnComplete(func(ret async.Result) {
+		value, err := resultT[T](ret)
+		generic.CastAction2(fun).Call(autoRecover, reportError, value, err)
+	})
+}
+
+// SubmitT 将返回 T 类型结果的函数投递到 provider 所属 Runtime，并返回 FutureT。
+func SubmitT[T any](provider corectx.ConcurrentContextProvider, fun func(ctx runtime.Context) (T, error)) FutureT[T] {
+	if fun == nil {
+		exception.Panicf("%w: %w: fun is nil", ErrCore, ErrArgs)
+	}
+	return asFutureT[T](runtime.Concurrent(provider), Submit(provider, func(ctx runtime.Context, _ ...any) async.Result {
+		return async.NewResult(fun(ctx))
+	}))
+}
+
+// ContinueOnT 是 ContinueOn 的类型化版本：future 完成后在 provider 所属 Runtime 中以 T 类型结果调用 fun。
+func ContinueOnT[T, R any](provider corectx.ConcurrentContextProvider, future FutureT[T], fun func(ctx runtime.Context, value T, err error) (R, error)) FutureT[R] {
+	if fun == nil {
+		exception.Panicf("%w: %w: continuation is nil", ErrCore, ErrArgs)
+	}
+	return asFutureT[R](runtime.Concurrent(provider), ContinueOn(provider, future.future, func(ctx runtime.Context, ret async.Result, _ ...any) async.Result {
+		value, err := resultT[T](ret)
+		return async.NewResult(fun(ctx, value, err))
+	}))
+}
+
+// SpawnT 在 provider 的生命周期 Scope 中启动返回 T 类型结果的后台 goroutine。
+// fun 不得直接访问 Runtime 局部状态。
+func SpawnT[T any](provider corectx.AsyncScopeProvider, fun func(ctx context.Context) (T, error)) FutureT[T] {
+	if fun == nil {
+		exception.Panicf("%w: %w: fun is nil", ErrCore, ErrArgs)
+	}
+	var rtCtx corectx.Context
+	if p, ok := provider.(corectx.ConcurrentContextProvider); ok {
+		rtCtx = runtime.Concurrent(p)
+	}
+	return asFutureT[T](rtCtx, Spawn(provider, func(ctx context.Context, _ ...any) async.Result {
+		return async.NewResult(fun(ctx))
+	}))
+}
+
+func resultT[T any](ret async.Result) (T, error) {
+	var zero T
+	if ret.Error != nil {
+		return zero, ret.Error
+	}
+	if ret.Value == nil {
+		return zero, nil
+	}
+	value, ok := ret.Value.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: got %T, want %s", ErrResultType, ret.Value, reflect.TypeFor[T]())
+	}
+	return value, nil
+}
@@ -0,0 +1,76 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/tiny/runtime"
+)
+
+func TestFutureTMismatchedResultType(t *testing.T) {
+	rt := startTestRuntime(t, runtime.NewContext())
+
+	future := rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		return async.NewResult("text", nil)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	value, err := AsFutureT[int](future).Wait(ctx)
+	if !errors.Is(err, ErrResultType) || value != 0 {
+		t.Fatalf("wait: got %v, %v, want 0, %v", value, err, ErrResultType)
+	}
+	if !strings.Contains(err.Error(), "got string, want int") {
+		t.Fatalf("wait: got %q, want the result and expected types", err)
+	}
+
+	// 接口类型的零值没有动态类型，期望类型仍须正确报告
+	_, err = AsFutureT[error](future).Wait(ctx)
+	if !errors.Is(err, ErrResultType) || !strings.Contains(err.Error(), "want error") {
+		t.Fatalf("wait: got %v, want %v with the expected interface type", err, ErrResultType)
+	}
+}
+
+func TestFutureTOnCompleteRecoversPanic(t *testing.T) {
+	reportError := make(chan error, 1)
+	rt := startTestRuntime(t, runtime.NewContext(runtime.With.PanicHandling(true, reportError)))
+
+	SubmitT(rt.ctx, func(ctx runtime.Context) (int, error) {
+		return 1, nil
+	}).OnComplete(func(int, error) {
+		panic("on complete")
+	})
+
+	select {
+	case err := <-reportError:
+		if !strings.Contains(err.Error(), "on complete") {
+			t.Fatalf("reported error: got %v, want the OnComplete panic", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("OnComplete panic not reported")
+	}
+}
@@ -41,6 +41,20 @@ type LifecycleComponentUpdate = eventUpdate
 // LifecycleComponentLateUpdate 在每帧普通更新结束后接收后置更新。
 type LifecycleComponentLateUpdate = eventLateUpdate
 
+// LifecycleComponentFixedUpdate 在启用帧循环且组件处于 Alive 状态时按固定步长接收更新，每帧可执行零次或多次，先于 Update。
+type LifecycleComponentFixedUpdate = eventFixedUpdate
+
+// LifecycleComponentLockstepInput 在 Lockstep 模式下每帧开始时接收该帧收集到的全部参与者输入，先于 FixedUpdate 与 Update。
+type LifecycleComponentLockstepInput = eventLockstepInput
+
+// LifecycleComponentFramePhases 声明组件订阅的自定义帧阶段，与组件描述中 pt.ComponentDescriptor.SetFramePhases 记录的阶段合并。
+type LifecycleComponentFramePhases interface {
+	FramePhases() []string
+}
+
+// LifecycleComponentPhaseUpdate 在组件订阅的自定义帧阶段执行时调用，phase 为阶段名。
+type LifecycleComponentPhaseUpdate = eventPhaseUpdate
+
 // LifecycleComponentShut 在已进入过 Start 的组件处于 Shutting 状态时调用，与 LifecycleComponentStart 成对。
 type LifecycleComponentShut interface {
 	Shut()
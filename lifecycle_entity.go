@@ -35,6 +35,20 @@ type LifecycleEntityUpdate = eventUpdate
 // LifecycleEntityLateUpdate 在每帧普通更新结束后接收后置更新。
 type LifecycleEntityLateUpdate = eventLateUpdate
 
+// LifecycleEntityFixedUpdate 在启用帧循环且实体处于 Alive 状态时按固定步长接收更新，每帧可执行零次或多次，先于 Update。
+type LifecycleEntityFixedUpdate = eventFixedUpdate
+
+// LifecycleEntityLockstepInput 在 Lockstep 模式下每帧开始时接收该帧收集到的全部参与者输入，先于 FixedUpdate 与 Update。
+type LifecycleEntityLockstepInput = eventLockstepInput
+
+// LifecycleEntityFramePhases 声明实体订阅的自定义帧阶段。
+type LifecycleEntityFramePhases interface {
+	FramePhases() []string
+}
+
+// LifecycleEntityPhaseUpdate 在实体订阅的自定义帧阶段执行时调用，phase 为阶段名。
+type LifecycleEntityPhaseUpdate = eventPhaseUpdate
+
 // LifecycleEntityShut 在已进入过 Start 的实体处于 Shutting 状态时调用，与 LifecycleEntityStart 成对。
 type LifecycleEntityShut interface {
 	Shut()
@@ -0,0 +1,217 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/core/utils/uid"
+)
+
+var (
+	ErrRuntimeRegistered = fmt.Errorf("%w: runtime id is already registered", ErrRuntime) // 注册表中已存在相同 ID 的运行时。
+)
+
+// DefaultRegistry 进程级默认运行时注册表，通过 With.Runtime.Registry(DefaultRegistry) 启用。
+var DefaultRegistry = NewRegistry()
+
+// RegistryEvent 注册表变更类型。
+type RegistryEvent int8
+
+const (
+	RegistryEvent_Registered   RegistryEvent = iota // 运行时已注册。
+	RegistryEvent_Unregistered                      // 运行时已注销。
+)
+
+// RegistryChange 注册表变更通知。
+type RegistryChange struct {
+	Event   RegistryEvent // 变更类型。
+	Runtime Runtime       // 变更的运行时。
+}
+
+// RegistryWatcher 注册表变更回调，在注册或注销运行时的 goroutine 中调用。
+type RegistryWatcher = generic.Action1[RegistryChange]
+
+// NewRegistry 创建运行时注册表。
+func NewRegistry() *Registry {
+	return &Registry{
+		byID:     map[uid.ID]Runtime{},
+		byName:   map[string][]Runtime{},
+		watchers: map[int64]RegistryWatcher{},
+	}
+}
+
+// Registry 并发安全的运行时注册表，按持久化 ID 与名称查询运行时。
+// 设置 With.Runtime.Registry 后，运行时在 RunningEvent_Started 时注册，在 RunningEvent_Terminated 时注销。
+type Registry struct {
+	mutex     sync.RWMutex
+	byID      map[uid.ID]Runtime
+	byName    map[string][]Runtime
+	watchers  map[int64]RegistryWatcher
+	watcherID int64
+}
+
+// Register 注册运行时，ID 已存在时返回 ErrRuntimeRegistered。
+func (r *Registry) Register(rt Runtime) error {
+	if rt == nil {
+		exception.Panicf("%w: %w: rt is nil", ErrRuntime, ErrArgs)
+	}
+
+	ctx := rt.Context()
+
+	r.mutex.Lock()
+	if _, ok := r.byID[ctx.ID()]; ok {
+		r.mutex.Unlock()
+		return fmt.Errorf("%w: %q", ErrRuntimeRegistered, ctx.ID())
+	}
+	r.byID[ctx.ID()] = rt
+	r.byName[ctx.Name()] = append(r.byName[ctx.Name()], rt)
+	watchers := r.copyWatchers()
+	r.mutex.Unlock()
+
+	r.notify(watchers, RegistryChange{Event: RegistryEvent_Registered, Runtime: rt})
+	return nil
+}
+
+// Unregister 注销运行时，运行时未注册时返回 false。
+func (r *Registry) Unregister(rt Runtime) bool {
+	if rt == nil {
+		return false
+	}
+
+	ctx := rt.Context()
+
+	r.mutex.Lock()
+	if cur, ok := r.byID[ctx.ID()]; !ok || cur != rt {
+		r.mutex.Unlock()
+		return false
+	}
+	delete(r.byID, ctx.ID())
+	if named := slices.DeleteFunc(r.byName[ctx.Name()], func(v Runtime) bool { return v == rt }); len(named) > 0 {
+		r.byName[ctx.Name()] = named
+	} else {
+		delete(r.byName, ctx.Name())
+	}
+	watchers := r.copyWatchers()
+	r.mutex.Unlock()
+
+	r.notify(watchers, RegistryChange{Event: RegistryEvent_Unregistered, Runtime: rt})
+	return true
+}
+
+// Get 按持久化 ID 查询运行时。
+func (r *Registry) Get(id uid.ID) (Runtime, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	rt, ok := r.byID[id]
+	return rt, ok
+}
+
+// GetByName 按名称查询运行时；多个运行时同名时返回最早注册的一个。
+func (r *Registry) GetByName(name string) (Runtime, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	named := r.byName[name]
+	if len(named) <= 0 {
+		return nil, false
+	}
+	return named[0], true
+}
+
+// ListByName 按注册顺序返回全部同名运行时。
+func (r *Registry) ListByName(name string) []Runtime {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return slices.Clone(r.byName[name])
+}
+
+// Range 遍历已注册的运行时，回调返回 false 时停止；遍历的是调用时的副本。
+func (r *Registry) Range(fun generic.Func1[Runtime, bool]) {
+	r.mutex.RLock()
+	runtimes := make([]Runtime, 0, len(r.byID))
+	for _, rt := range r.byID {
+		runtimes = append(runtimes, rt)
+	}
+	r.mutex.RUnlock()
+
+	for _, rt := range runtimes {
+		if !fun.UnsafeCall(rt) {
+			return
+		}
+	}
+}
+
+// Count 返回已注册的运行时数量。
+func (r *Registry) Count() int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return len(r.byID)
+}
+
+// Watch 订阅注册表变更，返回取消订阅的函数。
+// 回调在注册或注销运行时的 goroutine 中同步调用，不应阻塞。
+func (r *Registry) Watch(watcher RegistryWatcher) (cancel func()) {
+	if watcher == nil {
+		exception.Panicf("%w: %w: watcher is nil", ErrRuntime, ErrArgs)
+	}
+
+	r.mutex.Lock()
+	r.watcherID++
+	id := r.watcherID
+	r.watchers[id] = watcher
+	r.mutex.Unlock()
+
+	return func() {
+		r.mutex.Lock()
+		delete(r.watchers, id)
+		r.mutex.Unlock()
+	}
+}
+
+func (r *Registry) copyWatchers() []RegistryWatcher {
+	if len(r.watchers) <= 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(r.watchers))
+	for id := range r.watchers {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	watchers := make([]RegistryWatcher, 0, len(ids))
+	for _, id := range ids {
+		watchers = append(watchers, r.watchers[id])
+	}
+	return watchers
+}
+
+func (r *Registry) notify(watchers []RegistryWatcher, change RegistryChange) {
+	ctx := change.Runtime.Context()
+	for _, watcher := range watchers {
+		watcher.Call(ctx.AutoRecover(), ctx.ReportError(), change)
+	}
+}
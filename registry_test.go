@@ -0,0 +1,141 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"git.golaxy.org/core/utils/uid"
+	"git.golaxy.org/tiny/runtime"
+)
+
+func newTestRegistryRuntime(name string, persistID uid.ID) Runtime {
+	return NewRuntime(runtime.NewContext(runtime.With.Name(name), runtime.With.PersistID(persistID)))
+}
+
+func TestRegistryDuplicateID(t *testing.T) {
+	r := NewRegistry()
+	persistID := uid.New()
+
+	rt := newTestRegistryRuntime("a", persistID)
+	if err := r.Register(rt); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register(newTestRegistryRuntime("b", persistID)); !errors.Is(err, ErrRuntimeRegistered) {
+		t.Fatalf("register duplicate id: got %v, want %v", err, ErrRuntimeRegistered)
+	}
+
+	if got, ok := r.Get(persistID); !ok || got != rt {
+		t.Fatalf("get: got %v, want the first runtime", got)
+	}
+	if _, ok := r.GetByName("b"); ok {
+		t.Fatal("rejected runtime registered by name")
+	}
+}
+
+func TestRegistryDuplicateName(t *testing.T) {
+	r := NewRegistry()
+
+	runtimes := []Runtime{
+		newTestRegistryRuntime("a", uid.New()),
+		newTestRegistryRuntime("a", uid.New()),
+		newTestRegistryRuntime("a", uid.New()),
+	}
+	for _, rt := range runtimes {
+		if err := r.Register(rt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, ok := r.GetByName("a"); !ok || got != runtimes[0] {
+		t.Fatalf("get by name: got %v, want the earliest registered", got)
+	}
+	if got := r.ListByName("a"); !slices.Equal(got, runtimes) {
+		t.Fatalf("list by name: got %v, want %v", got, runtimes)
+	}
+
+	// 注销最早注册的运行时后，按注册顺序返回下一个
+	if !r.Unregister(runtimes[0]) {
+		t.Fatal("unregister failed")
+	}
+	if got, ok := r.GetByName("a"); !ok || got != runtimes[1] {
+		t.Fatalf("get by name after unregister: got %v, want the second registered", got)
+	}
+	if got := r.ListByName("a"); !slices.Equal(got, runtimes[1:]) {
+		t.Fatalf("list by name after unregister: got %v, want %v", got, runtimes[1:])
+	}
+}
+
+func TestRegistryUnregisterMismatch(t *testing.T) {
+	r := NewRegistry()
+	persistID := uid.New()
+
+	rt := newTestRegistryRuntime("a", persistID)
+	if err := r.Register(rt); err != nil {
+		t.Fatal(err)
+	}
+
+	// 相同 ID 的其他实例不能注销已注册的运行时
+	if r.Unregister(newTestRegistryRuntime("a", persistID)) {
+		t.Fatal("unregister mismatched instance: got true, want false")
+	}
+	if r.Unregister(newTestRegistryRuntime("a", uid.New())) {
+		t.Fatal("unregister unknown runtime: got true, want false")
+	}
+	if got, ok := r.Get(persistID); !ok || got != rt || r.Count() != 1 {
+		t.Fatalf("get after mismatched unregister: got %v, count %d", got, r.Count())
+	}
+}
+
+func TestRegistryWatch(t *testing.T) {
+	r := NewRegistry()
+
+	var changes []RegistryChange
+	cancel := r.Watch(func(change RegistryChange) {
+		changes = append(changes, change)
+	})
+
+	rt := newTestRegistryRuntime("a", uid.New())
+	if err := r.Register(rt); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Unregister(rt) {
+		t.Fatal("unregister failed")
+	}
+
+	want := []RegistryChange{
+		{Event: RegistryEvent_Registered, Runtime: rt},
+		{Event: RegistryEvent_Unregistered, Runtime: rt},
+	}
+	if !slices.Equal(changes, want) {
+		t.Fatalf("changes: got %v, want %v", changes, want)
+	}
+
+	cancel()
+
+	if err := r.Register(rt); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("watcher called after cancel: got %v", changes[len(want):])
+	}
+}
@@ -21,7 +21,6 @@ package tiny
 
 import (
 	"sync/atomic"
-	"time"
 
 	"git.golaxy.org/core/event"
 	"git.golaxy.org/core/utils/corectx"
@@ -32,6 +31,7 @@ import (
 	"git.golaxy.org/core/utils/reinterpret"
 	"git.golaxy.org/tiny/ec"
 	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/clock"
 )
 
 // NewRuntime 创建运行时并将 rtCtx 绑定到该运行时。
@@ -80,16 +80,26 @@ type RuntimeBehavior struct {
 	ctx                                                  runtime.Context
 	options                                              RuntimeOptions
 	isRunning                                            atomic.Bool
+	gracefulTermination                                  atomic.Pointer[_GracefulTermination]
 	frame                                                *_Frame
 	taskQueue                                            _TaskQueue
+	taskScheduler                                        _TaskScheduler
 	handleEventEntityManagerAddEntity                    runtime.EventEntityManagerAddEntity
 	handleEventEntityManagerRemoveEntity                 runtime.EventEntityManagerRemoveEntity
 	handleEventEntityManagerEntityAddComponents          runtime.EventEntityManagerEntityAddComponents
 	handleEventEntityManagerEntityRemoveComponent        runtime.EventEntityManagerEntityRemoveComponent
 	handleEventEntityManagerEntityComponentEnableChanged runtime.EventEntityManagerEntityComponentEnableChanged
 	handleEventEntityManagerEntityFirstTouchComponent    runtime.EventEntityManagerEntityFirstTouchComponent
+	handleEventEntityManagerEntityUpdateIntervalChanged  runtime.EventEntityManagerEntityUpdateIntervalChanged
 	managedAddInManagerHandles                           [2]event.Handle
 	lastProgressTime                                     atomic.Int64
+	framePhases                                          []_FramePhase
+	watchdog                                             _Watchdog
+	taskBudget                                           _TaskBudget
+	clock                                                clock.Clock
+	lockstep                                             _Lockstep
+	rollback                                             _Rollback
+	commandSeq                                           int64
 
 	runtimeEventTab runtimeEventTab
 }
@@ -125,7 +135,9 @@ func (rt *RuntimeBehavior) init(rtCtx runtime.Context, options RuntimeOptions) {
 
 	rt.ctx = rtCtx
 	rt.options = options
-	rt.lastProgressTime.Store(time.Now().UnixNano())
+
+	rt.clock = rtCtx.Clock()
+	rt.lastProgressTime.Store(rt.clock.Now().UnixNano())
 
 	if rt.options.InstanceFace.IsNil() {
 		rt.options.InstanceFace = iface.NewFaceT[Runtime](rt)
@@ -133,16 +145,33 @@ func (rt *RuntimeBehavior) init(rtCtx runtime.Context, options RuntimeOptions) {
 
 	if rt.options.Frame.Mode != FrameMode_Disabled {
 		rt.frame = &_Frame{}
-		rt.frame.init(rt.options.Frame.TargetFPS, rt.options.Frame.TotalFrames)
+		rt.frame.init(rt.options.Frame, rt.clock)
 		runtime.UnsafeContext(rtCtx).SetFrame(rt.frame)
 	} else {
 		runtime.UnsafeContext(rtCtx).SetFrame(nil)
 	}
 
-	rt.taskQueue.init(rt.options.TaskQueue.Unbounded, rt.options.TaskQueue.Capacity)
+	rt.taskQueue.init(rt.options.TaskQueue, rt.clock)
+	if rt.options.Frame.Mode == FrameMode_Realtime {
+		rt.taskBudget.init(rt.options.TaskQueue)
+		if rt.taskBudget.enabled() {
+			rt.taskQueue.enableFrameChan()
+		}
+	}
+	rt.taskScheduler.init(rt.clock)
+	if rt.options.Frame.Mode == FrameMode_Lockstep {
+		rt.lockstep.init(rt.clock, rt.options.Frame.LockstepTimeout, rt.options.Frame.LockstepMaxLead)
+	}
+	if rt.options.Frame.Mode == FrameMode_Manual {
+		rt.rollback.init(rt.options.Frame)
+	}
+	rt.watchdog.init(rt)
 	runtime.UnsafeContext(rtCtx).SetCaller(rt.getInstance())
 
 	rt.runtimeEventTab.SetPanicHandling(rtCtx.AutoRecover(), rtCtx.ReportError())
+	if rt.frame != nil {
+		rt.initFramePhases()
+	}
 
 	rt.handleEventEntityManagerAddEntity = runtime.HandleEventEntityManagerAddEntity(rt.onEntityManagerAddEntity)
 	rt.handleEventEntityManagerRemoveEntity = runtime.HandleEventEntityManagerRemoveEntity(rt.onEntityManagerRemoveEntity)
@@ -150,6 +179,7 @@ func (rt *RuntimeBehavior) init(rtCtx runtime.Context, options RuntimeOptions) {
 	rt.handleEventEntityManagerEntityRemoveComponent = runtime.HandleEventEntityManagerEntityRemoveComponent(rt.onEntityManagerEntityRemoveComponent)
 	rt.handleEventEntityManagerEntityComponentEnableChanged = runtime.HandleEventEntityManagerEntityComponentEnableChanged(rt.onEntityManagerEntityComponentEnableChanged)
 	rt.handleEventEntityManagerEntityFirstTouchComponent = runtime.HandleEventEntityManagerEntityFirstTouchComponent(rt.onEntityManagerEntityFirstTouchComponent)
+	rt.handleEventEntityManagerEntityUpdateIntervalChanged = runtime.HandleEventEntityManagerEntityUpdateIntervalChanged(rt.onEntityManagerEntityUpdateIntervalChanged)
 
 	runtime.BindEventContextRunningEvent(rtCtx, runtime.HandleEventContextRunningEvent(rt.onBeforeContextRunningEvent), -100)
 	runtime.BindEventContextRunningEvent(rtCtx, runtime.HandleEventContextRunningEvent(rt.onAfterContextRunningEvent), 100)
@@ -165,6 +195,16 @@ func (rt *RuntimeBehavior) getInstance() Runtime {
 	return rt.options.InstanceFace.Iface
 }
 
+// reportError 以非阻塞方式将错误发送到运行时上下文的 ReportError。
+func (rt *RuntimeBehavior) reportError(err error) {
+	if reportError := rt.ctx.ReportError(); reportError != nil {
+		select {
+		case reportError <- err:
+		default:
+		}
+	}
+}
+
 // onEntityManagerAddEntity 在实体加入 Runtime 管理器后推进其激活流程。
 func (rt *RuntimeBehavior) onEntityManagerAddEntity(entityManager runtime.EntityManager, entity ec.Entity) {
 	if entity.State() != ec.EntityState_Entered {
@@ -474,21 +514,65 @@ func (rt *RuntimeBehavior) onEntityManagerEntityFirstTouchComponent(entityManage
 	}
 }
 
+// onEntityManagerEntityUpdateIntervalChanged 按新的更新间隔重新绑定实体及其已启动组件的帧更新回调。
+func (rt *RuntimeBehavior) onEntityManagerEntityUpdateIntervalChanged(entityManager runtime.EntityManager, entity ec.Entity, interval int64) {
+	if entity.State() < ec.EntityState_Awaking || entity.State() > ec.EntityState_Alive {
+		return
+	}
+
+	rt.observeEntityUpdates(entity)
+
+	ec.UnsafeEntity(entity).ComponentList().TraversalEach(func(slot *generic.FreeSlot[ec.Component]) {
+		comp := slot.V
+		if comp.State() == ec.ComponentState_Starting || comp.State() == ec.ComponentState_Alive {
+			rt.observeComponentUpdates(comp)
+		}
+	})
+}
+
 func (rt *RuntimeBehavior) observeEntity(entity ec.Entity) {
+	rt.observeEntityUpdates(entity)
+	if cb, ok := entity.(LifecycleEntityFixedUpdate); ok {
+		ec.UnsafeEntity(entity).ManagedRuntimeFixedUpdateHandle(_BindEventFixedUpdate(&rt.runtimeEventTab, rt.watchFixedUpdate(entity, nil, cb)))
+	}
+	if cb, ok := entity.(LifecycleEntityLockstepInput); ok {
+		ec.UnsafeEntity(entity).ManagedRuntimeLockstepInputHandle(_BindEventLockstepInput(&rt.runtimeEventTab, rt.watchLockstepInput(entity, nil, cb)))
+	}
+}
+
+// observeEntityUpdates 绑定实体受更新间隔控制的帧更新回调。
+func (rt *RuntimeBehavior) observeEntityUpdates(entity ec.Entity) {
 	if cb, ok := entity.(LifecycleEntityUpdate); ok {
-		ec.UnsafeEntity(entity).ManagedRuntimeUpdateHandle(_BindEventUpdate(&rt.runtimeEventTab, cb))
+		ec.UnsafeEntity(entity).ManagedRuntimeUpdateHandle(_BindEventUpdate(&rt.runtimeEventTab, rt.throttleUpdate(entity, nil, cb)))
 	}
 	if cb, ok := entity.(LifecycleEntityLateUpdate); ok {
-		ec.UnsafeEntity(entity).ManagedRuntimeLateUpdateHandle(_BindEventLateUpdate(&rt.runtimeEventTab, cb))
+		ec.UnsafeEntity(entity).ManagedRuntimeLateUpdateHandle(_BindEventLateUpdate(&rt.runtimeEventTab, rt.throttleLateUpdate(entity, nil, cb)))
+	}
+	if cb, ok := entity.(LifecycleEntityPhaseUpdate); ok {
+		ec.UnsafeEntity(entity).ManagedRuntimePhaseHandles(rt.bindFramePhases(rt.throttlePhaseUpdate(entity, nil, cb), entityFramePhases(entity)))
 	}
 }
 
 func (rt *RuntimeBehavior) observeComponent(comp ec.Component) {
+	rt.observeComponentUpdates(comp)
+	if cb, ok := comp.(LifecycleComponentFixedUpdate); ok {
+		ec.UnsafeComponent(comp).ManagedRuntimeFixedUpdateHandle(_BindEventFixedUpdate(&rt.runtimeEventTab, rt.watchFixedUpdate(comp.Entity(), comp, cb)))
+	}
+	if cb, ok := comp.(LifecycleComponentLockstepInput); ok {
+		ec.UnsafeComponent(comp).ManagedRuntimeLockstepInputHandle(_BindEventLockstepInput(&rt.runtimeEventTab, rt.watchLockstepInput(comp.Entity(), comp, cb)))
+	}
+}
+
+// observeComponentUpdates 绑定组件受更新间隔控制的帧更新回调。
+func (rt *RuntimeBehavior) observeComponentUpdates(comp ec.Component) {
 	if cb, ok := comp.(LifecycleComponentUpdate); ok {
-		ec.UnsafeComponent(comp).ManagedRuntimeUpdateHandle(_BindEventUpdate(&rt.runtimeEventTab, cb))
+		ec.UnsafeComponent(comp).ManagedRuntimeUpdateHandle(_BindEventUpdate(&rt.runtimeEventTab, rt.throttleUpdate(comp.Entity(), comp, cb)))
 	}
 	if cb, ok := comp.(LifecycleComponentLateUpdate); ok {
-		ec.UnsafeComponent(comp).ManagedRuntimeLateUpdateHandle(_BindEventLateUpdate(&rt.runtimeEventTab, cb))
+		ec.UnsafeComponent(comp).ManagedRuntimeLateUpdateHandle(_BindEventLateUpdate(&rt.runtimeEventTab, rt.throttleLateUpdate(comp.Entity(), comp, cb)))
+	}
+	if cb, ok := comp.(LifecycleComponentPhaseUpdate); ok {
+		ec.UnsafeComponent(comp).ManagedRuntimePhaseHandles(rt.bindFramePhases(rt.throttlePhaseUpdate(comp.Entity(), comp, cb), componentFramePhases(comp)))
 	}
 }
 
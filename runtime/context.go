@@ -23,6 +23,7 @@ import (
 	"context"
 	"reflect"
 	"sync/atomic"
+	"time"
 
 	"git.golaxy.org/core/event"
 	"git.golaxy.org/core/extension"
@@ -32,7 +33,9 @@ import (
 	"git.golaxy.org/core/utils/option"
 	"git.golaxy.org/core/utils/reinterpret"
 	"git.golaxy.org/core/utils/uid"
+	"git.golaxy.org/tiny/ec"
 	"git.golaxy.org/tiny/ec/pt"
+	"git.golaxy.org/tiny/utils/clock"
 	"git.golaxy.org/tiny/utils/id"
 )
 
@@ -82,8 +85,20 @@ type Context interface {
 	EntityManager() EntityManager
 	// EntityTree 返回当前运行时的实体树。
 	EntityTree() EntityTree
+	// Timers 返回运行时本地的定时器集合。
+	Timers() Timers
 	// Managed 返回随运行时上下文统一解绑的事件句柄集合。
 	Managed() *event.ManagedHandles
+	// Snapshot 捕获实体、组件、实体树、ID 生成器与帧计数，组件数据通过 codec 编码。
+	Snapshot(codec ComponentCodec) (*Snapshot, error)
+	// Restore 将快照恢复到尚未持有实体的运行时上下文，组件数据通过 codec 解码。
+	Restore(snapshot *Snapshot, codec ComponentCodec) error
+	// SnapshotEntity 捕获单个存活实体及其组件，组件数据通过 codec 编码。
+	SnapshotEntity(entityID id.ID, codec ComponentCodec) (*EntitySnapshot, error)
+	// RestoreEntity 按实体快照重建实体并加入运行时，组件数据通过 codec 解码。
+	RestoreEntity(entitySnapshot *EntitySnapshot, codec ComponentCodec) (ec.Entity, error)
+	// ValidateEntity 按实体快照重建实体并解码组件数据，但不加入运行时，用于检查快照能否恢复。
+	ValidateEntity(entitySnapshot *EntitySnapshot, codec ComponentCodec) error
 
 	IContextRunningEventTab
 }
@@ -96,6 +111,8 @@ type iContext interface {
 	setCaller(caller Caller)
 	getAddInManager() AddInManager
 	getScoped() *atomic.Bool
+	advanceTimers(elapsed time.Duration, frames int64)
+	countTimers() int
 	gc()
 }
 
@@ -108,6 +125,7 @@ type ContextBehavior struct {
 	idGenerator    int64
 	frame          Frame
 	entityManager  _EntityManager
+	timers         _Timers
 	caller         Caller
 	scoped         atomic.Bool
 	gcList         []GC
@@ -162,6 +180,11 @@ func (ctx *ContextBehavior) EntityTree() EntityTree {
 	return &ctx.entityManager
 }
 
+// Timers 返回运行时本地的定时器集合。
+func (ctx *ContextBehavior) Timers() Timers {
+	return ctx.timers.scope(nil)
+}
+
 // Managed 返回随运行时上下文统一解绑的事件句柄集合。
 func (ctx *ContextBehavior) Managed() *event.ManagedHandles {
 	return &ctx.managed
@@ -223,6 +246,10 @@ func (ctx *ContextBehavior) init(options ContextOptions) {
 		ctx.options.PersistID = uid.New()
 	}
 
+	if ctx.options.Clock == nil {
+		ctx.options.Clock = clock.Real()
+	}
+
 	if ctx.options.AddInManager == nil {
 		ctx.options.AddInManager = NewAddInManager()
 	}
@@ -237,6 +264,7 @@ func (ctx *ContextBehavior) init(options ContextOptions) {
 	ctx.contextRunningEventTab.SetPanicHandling(ctx.AutoRecover(), ctx.ReportError())
 
 	ctx.entityManager.init(ctx.getInstance())
+	ctx.timers.init(ctx.getInstance())
 	event.UnsafeEvent(ctx.EntityLib().EventEntityLibDeclareEntityPT()).Ctrl().SetPanicHandling(ctx.AutoRecover(), ctx.ReportError())
 	event.UnsafeEvent(ctx.EntityLib().ComponentLib().EventComponentLibDeclareComponentPT()).Ctrl().SetPanicHandling(ctx.AutoRecover(), ctx.ReportError())
 
@@ -248,6 +276,7 @@ func (ctx *ContextBehavior) init(options ContextOptions) {
 		BindEventContextRunningEvent(ctx, HandleEventContextRunningEvent(ctx.options.RunningEventCB))
 	}
 	BindEventContextRunningEvent(ctx, HandleEventContextRunningEvent(ctx.entityManager.onContextRunningEvent))
+	BindEventContextRunningEvent(ctx, HandleEventContextRunningEvent(ctx.timers.onContextRunningEvent))
 }
 
 func (ctx *ContextBehavior) getOptions() *ContextOptions {
@@ -275,3 +304,11 @@ func (ctx *ContextBehavior) setCaller(caller Caller) {
 func (ctx *ContextBehavior) getScoped() *atomic.Bool {
 	return &ctx.scoped
 }
+
+func (ctx *ContextBehavior) advanceTimers(elapsed time.Duration, frames int64) {
+	ctx.timers.advance(elapsed, frames)
+}
+
+func (ctx *ContextBehavior) countTimers() int {
+	return ctx.timers.count()
+}
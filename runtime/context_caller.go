@@ -20,7 +20,9 @@
 package runtime
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"git.golaxy.org/core/utils/async"
 	"git.golaxy.org/core/utils/generic"
@@ -39,6 +41,21 @@ type Caller interface {
 	SubmitDelegateVoid(fun generic.DelegateVoidVar1[Context, any], args ...any) async.Future
 	Post(fun generic.ActionVar1[Context, any], args ...any) error
 	PostDelegate(fun generic.DelegateVoidVar1[Context, any], args ...any) error
+	SubmitPriority(priority TaskPriority, fun generic.FuncVar1[Context, any, async.Result], args ...any) async.Future
+	SubmitVoidPriority(priority TaskPriority, fun generic.ActionVar1[Context, any], args ...any) async.Future
+	PostPriority(priority TaskPriority, fun generic.ActionVar1[Context, any], args ...any) error
+	SubmitCtx(ctx context.Context, fun generic.FuncVar1[Context, any, async.Result], args ...any) async.Future
+	SubmitVoidCtx(ctx context.Context, fun generic.ActionVar1[Context, any], args ...any) async.Future
+	SubmitWait(ctx context.Context, fun generic.FuncVar1[Context, any, async.Result], args ...any) async.Future
+	SubmitVoidWait(ctx context.Context, fun generic.ActionVar1[Context, any], args ...any) async.Future
+	PostWait(ctx context.Context, fun generic.ActionVar1[Context, any], args ...any) error
+	PostKeyed(key any, fun generic.ActionVar1[Context, any], args ...any) error
+	SubmitCancelable(fun generic.FuncVar1[Context, any, async.Result], args ...any) TaskHandle
+	SubmitVoidCancelable(fun generic.ActionVar1[Context, any], args ...any) TaskHandle
+	PostCancelable(fun generic.ActionVar1[Context, any], args ...any) (TaskHandle, error)
+	PostAfter(dur time.Duration, fun generic.ActionVar1[Context, any], args ...any) error
+	PostAtFrame(frame int64, fun generic.ActionVar1[Context, any], args ...any) error
+	PostAfterFrames(frames int64, fun generic.ActionVar1[Context, any], args ...any) error
 }
 
 func (ctx *ContextBehavior) Submit(fun generic.FuncVar1[Context, any, async.Result], args ...any) async.Future {
@@ -65,37 +82,94 @@ func (ctx *ContextBehavior) PostDelegate(fun generic.DelegateVoidVar1[Context, a
 	return ctx.caller.PostDelegate(fun, args...)
 }
 
-func checkEntity(entity ec.Entity) error {
-	if entity.State() > ec.EntityState_Alive {
-		return fmt.Errorf("%w: entity is in an unexpected state %q", ErrContext, entity.State())
-	}
-	return nil
+func (ctx *ContextBehavior) SubmitPriority(priority TaskPriority, fun generic.FuncVar1[Context, any, async.Result], args ...any) async.Future {
+	return ctx.caller.SubmitPriority(priority, fun, args...)
+}
+
+func (ctx *ContextBehavior) SubmitVoidPriority(priority TaskPriority, fun generic.ActionVar1[Context, any], args ...any) async.Future {
+	return ctx.caller.SubmitVoidPriority(priority, fun, args...)
+}
+
+func (ctx *ContextBehavior) PostPriority(priority TaskPriority, fun generic.ActionVar1[Context, any], args ...any) error {
+	return ctx.caller.PostPriority(priority, fun, args...)
+}
+
+func (ctx *ContextBehavior) SubmitCtx(taskCtx context.Context, fun generic.FuncVar1[Context, any, async.Result], args ...any) async.Future {
+	return ctx.caller.SubmitCtx(taskCtx, fun, args...)
+}
+
+func (ctx *ContextBehavior) SubmitVoidCtx(taskCtx context.Context, fun generic.ActionVar1[Context, any], args ...any) async.Future {
+	return ctx.caller.SubmitVoidCtx(taskCtx, fun, args...)
 }
 
-func submit(entity ec.ConcurrentEntity, fun generic.FuncVar1[ec.Entity, any, async.Result], args ...any) async.Future {
-	return Concurrent(entity).Submit(func(_ Context, args ...any) async.Result {
-		entity := ec.UnsafeConcurrentEntity(entity).Instance()
-		if err := checkEntity(entity); err != nil {
+func (ctx *ContextBehavior) SubmitWait(waitCtx context.Context, fun generic.FuncVar1[Context, any, async.Result], args ...any) async.Future {
+	return ctx.caller.SubmitWait(waitCtx, fun, args...)
+}
+
+func (ctx *ContextBehavior) SubmitVoidWait(waitCtx context.Context, fun generic.ActionVar1[Context, any], args ...any) async.Future {
+	return ctx.caller.SubmitVoidWait(waitCtx, fun, args...)
+}
+
+func (ctx *ContextBehavior) PostWait(waitCtx context.Context, fun generic.ActionVar1[Context, any], args ...any) error {
+	return ctx.caller.PostWait(waitCtx, fun, args...)
+}
+
+func (ctx *ContextBehavior) PostKeyed(key any, fun generic.ActionVar1[Context, any], args ...any) error {
+	return ctx.caller.PostKeyed(key, fun, args...)
+}
+
+func (ctx *ContextBehavior) SubmitCancelable(fun generic.FuncVar1[Context, any, async.Result], args ...any) TaskHandle {
+	return ctx.caller.SubmitCancelable(fun, args...)
+}
+
+func (ctx *ContextBehavior) SubmitVoidCancelable(fun generic.ActionVar1[Context, any], args ...any) TaskHandle {
+	return ctx.caller.SubmitVoidCancelable(fun, args...)
+}
+
+func (ctx *ContextBehavior) PostCancelable(fun generic.ActionVar1[Context, any], args ...any) (TaskHandle, error) {
+	return ctx.caller.PostCancelable(fun, args...)
+}
+
+func (ctx *ContextBehavior) PostAfter(dur time.Duration, fun generic.ActionVar1[Context, any], args ...any) error {
+	return ctx.caller.PostAfter(dur, fun, args...)
+}
+
+func (ctx *ContextBehavior) PostAtFrame(frame int64, fun generic.ActionVar1[Context, any], args ...any) error {
+	return ctx.caller.PostAtFrame(frame, fun, args...)
+}
+
+func (ctx *ContextBehavior) PostAfterFrames(frames int64, fun generic.ActionVar1[Context, any], args ...any) error {
+	return ctx.caller.PostAfterFrames(frames, fun, args...)
+}
+
+// SubmitEntity 将有返回值函数投递到实体所属 Runtime，以存活的实体调用 fun。
+// 执行时实体已离开 Alive 状态返回 ErrEntityNotAlive，实体 ID 已被其他实体复用返回 ErrEntityReplaced。
+func SubmitEntity(entity ec.ConcurrentEntity, fun generic.FuncVar1[ec.Entity, any, async.Result], args ...any) async.Future {
+	return Concurrent(entity).Submit(func(ctx Context, args ...any) async.Result {
+		entity, err := liveEntity(ctx, entity)
+		if err != nil {
 			return async.NewResult(nil, err)
 		}
 		return fun.UnsafeCall(entity, args...)
 	}, args...)
 }
 
-func submitDelegate(entity ec.ConcurrentEntity, fun generic.DelegateVar1[ec.Entity, any, async.Result], args ...any) async.Future {
-	return Concurrent(entity).Submit(func(_ Context, args ...any) async.Result {
-		entity := ec.UnsafeConcurrentEntity(entity).Instance()
-		if err := checkEntity(entity); err != nil {
+// SubmitEntityDelegate 是 SubmitEntity 的 Delegate 版本。
+func SubmitEntityDelegate(entity ec.ConcurrentEntity, fun generic.DelegateVar1[ec.Entity, any, async.Result], args ...any) async.Future {
+	return Concurrent(entity).Submit(func(ctx Context, args ...any) async.Result {
+		entity, err := liveEntity(ctx, entity)
+		if err != nil {
 			return async.NewResult(nil, err)
 		}
 		return fun.UnsafeCall(nil, entity, args...)
 	}, args...)
 }
 
-func submitVoid(entity ec.ConcurrentEntity, fun generic.ActionVar1[ec.Entity, any], args ...any) async.Future {
-	return Concurrent(entity).Submit(func(_ Context, args ...any) async.Result {
-		entity := ec.UnsafeConcurrentEntity(entity).Instance()
-		if err := checkEntity(entity); err != nil {
+// SubmitEntityVoid 将无业务返回值函数投递到实体所属 Runtime；实体检查同 SubmitEntity。
+func SubmitEntityVoid(entity ec.ConcurrentEntity, fun generic.ActionVar1[ec.Entity, any], args ...any) async.Future {
+	return Concurrent(entity).Submit(func(ctx Context, args ...any) async.Result {
+		entity, err := liveEntity(ctx, entity)
+		if err != nil {
 			return async.NewResult(nil, err)
 		}
 		fun.UnsafeCall(entity, args...)
@@ -103,10 +177,11 @@ func submitVoid(entity ec.ConcurrentEntity, fun generic.ActionVar1[ec.Entity, an
 	}, args...)
 }
 
-func submitDelegateVoid(entity ec.ConcurrentEntity, fun generic.DelegateVoidVar1[ec.Entity, any], args ...any) async.Future {
-	return Concurrent(entity).Submit(func(_ Context, args ...any) async.Result {
-		entity := ec.UnsafeConcurrentEntity(entity).Instance()
-		if err := checkEntity(entity); err != nil {
+// SubmitEntityDelegateVoid 是 SubmitEntityVoid 的 DelegateVoid 版本。
+func SubmitEntityDelegateVoid(entity ec.ConcurrentEntity, fun generic.DelegateVoidVar1[ec.Entity, any], args ...any) async.Future {
+	return Concurrent(entity).Submit(func(ctx Context, args ...any) async.Result {
+		entity, err := liveEntity(ctx, entity)
+		if err != nil {
 			return async.NewResult(nil, err)
 		}
 		fun.UnsafeCall(nil, entity, args...)
@@ -114,22 +189,123 @@ func submitDelegateVoid(entity ec.ConcurrentEntity, fun generic.DelegateVoidVar1
 	}, args...)
 }
 
-func post(entity ec.ConcurrentEntity, fun generic.ActionVar1[ec.Entity, any], args ...any) error {
-	return Concurrent(entity).Post(func(_ Context, args ...any) {
-		entity := ec.UnsafeConcurrentEntity(entity).Instance()
-		if checkEntity(entity) != nil {
+// PostEntity 将无返回值函数投递到实体所属 Runtime，不创建 Future；执行时实体检查失败则丢弃该任务。
+func PostEntity(entity ec.ConcurrentEntity, fun generic.ActionVar1[ec.Entity, any], args ...any) error {
+	return Concurrent(entity).Post(func(ctx Context, args ...any) {
+		entity, err := liveEntity(ctx, entity)
+		if err != nil {
 			return
 		}
 		fun.UnsafeCall(entity, args...)
 	}, args...)
 }
 
-func postDelegate(entity ec.ConcurrentEntity, fun generic.DelegateVoidVar1[ec.Entity, any], args ...any) error {
-	return Concurrent(entity).Post(func(_ Context, args ...any) {
-		entity := ec.UnsafeConcurrentEntity(entity).Instance()
-		if checkEntity(entity) != nil {
+// PostEntityDelegate 是 PostEntity 的 DelegateVoid 版本。
+func PostEntityDelegate(entity ec.ConcurrentEntity, fun generic.DelegateVoidVar1[ec.Entity, any], args ...any) error {
+	return Concurrent(entity).Post(func(ctx Context, args ...any) {
+		entity, err := liveEntity(ctx, entity)
+		if err != nil {
 			return
 		}
 		fun.UnsafeCall(nil, entity, args...)
 	}, args...)
 }
+
+// SubmitComponent 将有返回值函数投递到组件所属 Runtime，以存活的组件调用 fun。
+// 执行时组件已离开 Alive 状态返回 ErrComponentNotAlive；所属实体的检查同 SubmitEntity。
+func SubmitComponent(comp ec.ConcurrentComponent, fun generic.FuncVar1[ec.Component, any, async.Result], args ...any) async.Future {
+	return Concurrent(comp).Submit(func(ctx Context, args ...any) async.Result {
+		comp, err := liveComponent(ctx, comp)
+		if err != nil {
+			return async.NewResult(nil, err)
+		}
+		return fun.UnsafeCall(comp, args...)
+	}, args...)
+}
+
+// SubmitComponentDelegate 是 SubmitComponent 的 Delegate 版本。
+func SubmitComponentDelegate(comp ec.ConcurrentComponent, fun generic.DelegateVar1[ec.Component, any, async.Result], args ...any) async.Future {
+	return Concurrent(comp).Submit(func(ctx Context, args ...any) async.Result {
+		comp, err := liveComponent(ctx, comp)
+		if err != nil {
+			return async.NewResult(nil, err)
+		}
+		return fun.UnsafeCall(nil, comp, args...)
+	}, args...)
+}
+
+// SubmitComponentVoid 将无业务返回值函数投递到组件所属 Runtime；组件检查同 SubmitComponent。
+func SubmitComponentVoid(comp ec.ConcurrentComponent, fun generic.ActionVar1[ec.Component, any], args ...any) async.Future {
+	return Concurrent(comp).Submit(func(ctx Context, args ...any) async.Result {
+		comp, err := liveComponent(ctx, comp)
+		if err != nil {
+			return async.NewResult(nil, err)
+		}
+		fun.UnsafeCall(comp, args...)
+		return async.NewResult(nil, nil)
+	}, args...)
+}
+
+// SubmitComponentDelegateVoid 是 SubmitComponentVoid 的 DelegateVoid 版本。
+func SubmitComponentDelegateVoid(comp ec.ConcurrentComponent, fun generic.DelegateVoidVar1[ec.Component, any], args ...any) async.Future {
+	return Concurrent(comp).Submit(func(ctx Context, args ...any) async.Result {
+		comp, err := liveComponent(ctx, comp)
+		if err != nil {
+			return async.NewResult(nil, err)
+		}
+		fun.UnsafeCall(nil, comp, args...)
+		return async.NewResult(nil, nil)
+	}, args...)
+}
+
+// PostComponent 将无返回值函数投递到组件所属 Runtime，不创建 Future；执行时组件检查失败则丢弃该任务。
+func PostComponent(comp ec.ConcurrentComponent, fun generic.ActionVar1[ec.Component, any], args ...any) error {
+	return Concurrent(comp).Post(func(ctx Context, args ...any) {
+		comp, err := liveComponent(ctx, comp)
+		if err != nil {
+			return
+		}
+		fun.UnsafeCall(comp, args...)
+	}, args...)
+}
+
+// PostComponentDelegate 是 PostComponent 的 DelegateVoid 版本。
+func PostComponentDelegate(comp ec.ConcurrentComponent, fun generic.DelegateVoidVar1[ec.Component, any], args ...any) error {
+	return Concurrent(comp).Post(func(ctx Context, args ...any) {
+		comp, err := liveComponent(ctx, comp)
+		if err != nil {
+			return
+		}
+		fun.UnsafeCall(nil, comp, args...)
+	}, args...)
+}
+
+// liveEntity 在 Runtime goroutine 中确认实体仍处于存活状态，且其 ID 仍指向同一实体。
+func liveEntity(ctx Context, concurrent ec.ConcurrentEntity) (ec.Entity, error) {
+	return checkEntity(ctx, ec.UnsafeConcurrentEntity(concurrent).Instance())
+}
+
+func liveComponent(ctx Context, concurrent ec.ConcurrentComponent) (ec.Component, error) {
+	comp := ec.UnsafeConcurrentComponent(concurrent).Instance()
+	if comp.State() > ec.ComponentState_Alive {
+		return nil, fmt.Errorf("%w: component %q is in state %q", ErrComponentNotAlive, comp.Name(), comp.State())
+	}
+	if _, err := checkEntity(ctx, comp.Entity()); err != nil {
+		return nil, err
+	}
+	return comp, nil
+}
+
+func checkEntity(ctx Context, entity ec.Entity) (ec.Entity, error) {
+	cur, ok := ctx.EntityManager().GetEntity(entity.ID())
+	if ok && cur != entity {
+		return nil, fmt.Errorf("%w: entity %q", ErrEntityReplaced, entity.ID())
+	}
+	if entity.State() > ec.EntityState_Alive {
+		return nil, fmt.Errorf("%w: entity %q is in state %q", ErrEntityNotAlive, entity.ID(), entity.State())
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: entity %q not found", ErrEntityNotAlive, entity.ID())
+	}
+	return entity, nil
+}
@@ -27,6 +27,7 @@ import (
 	"git.golaxy.org/core/utils/exception"
 	"git.golaxy.org/core/utils/iface"
 	"git.golaxy.org/core/utils/uid"
+	"git.golaxy.org/tiny/utils/clock"
 )
 
 // ConcurrentContextProvider 提供可跨 goroutine 使用的运行时上下文缓存。
@@ -51,6 +52,8 @@ type ConcurrentContext interface {
 	BlockedFutureID() async.FutureID
 	// LastWaitRejectID 返回最近一次被 Runtime 等待规则拒绝的 Future ID。
 	LastWaitRejectID() async.FutureID
+	// Clock 返回运行时使用的时钟。
+	Clock() clock.Clock
 }
 
 type iConcurrentContext interface {
@@ -77,6 +80,11 @@ func (ctx *ContextBehavior) LastWaitRejectID() async.FutureID {
 	return async.FutureID(ctx.lastWaitReject.Load())
 }
 
+// Clock 返回运行时使用的时钟。
+func (ctx *ContextBehavior) Clock() clock.Clock {
+	return ctx.options.Clock
+}
+
 // String 实现 fmt.Stringer，返回包含运行时 ID 和名称的 JSON 文本。
 func (ctx *ContextBehavior) String() string {
 	if cached := ctx.stringerCache.Load(); cached != nil {
@@ -27,6 +27,7 @@ import (
 	"git.golaxy.org/core/utils/option"
 	"git.golaxy.org/core/utils/uid"
 	"git.golaxy.org/tiny/ec/pt"
+	"git.golaxy.org/tiny/utils/clock"
 )
 
 type (
@@ -44,6 +45,7 @@ type ContextOptions struct {
 	EntityLib      pt.EntityLib        // 当前 Runtime 使用的实体原型库；nil 时创建独立原型库。
 	AddInManager   AddInManager        // 运行时插件管理器；nil 时创建默认管理器。
 	RunningEventCB RunningEventCB      // 运行时运行事件回调。
+	Clock          clock.Clock         // 运行时使用的时钟；nil 时使用系统时钟。
 }
 
 // With 提供 Runtime 上下文选项构造器。
@@ -62,6 +64,7 @@ func (_ContextOption) Default() option.Setting[ContextOptions] {
 		With.EntityLib(nil).Apply(options)
 		With.AddInManager(nil).Apply(options)
 		With.RunningEventCB(nil).Apply(options)
+		With.Clock(nil).Apply(options)
 	}
 }
 
@@ -121,3 +124,10 @@ func (_ContextOption) RunningEventCB(cb RunningEventCB) option.Setting[ContextOp
 		options.RunningEventCB = cb
 	}
 }
+
+// Clock 设置运行时使用的时钟，nil 表示使用系统时钟；时钟在创建上下文时确定，绑定该上下文的 Runtime 均使用该时钟。
+func (_ContextOption) Clock(c clock.Clock) option.Setting[ContextOptions] {
+	return func(options *ContextOptions) {
+		options.Clock = c
+	}
+}
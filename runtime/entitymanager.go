@@ -207,6 +207,10 @@ func (mgr *_EntityManager) OnEntityDestroy(entity ec.Entity) {
 	mgr.onEntityDestroyIfVersion(ec.UnsafeEntity(entity).EnteredHandle())
 }
 
+func (mgr *_EntityManager) OnEntityUpdateIntervalChanged(entity ec.Entity, interval int64) {
+	_EmitEventEntityManagerEntityUpdateIntervalChanged(mgr, mgr, entity, interval)
+}
+
 func (mgr *_EntityManager) OnComponentManagerAddComponents(entity ec.Entity, components []ec.Component) {
 	for i := range components {
 		mgr.initComponent(entity, components[i])
@@ -259,6 +263,7 @@ func (mgr *_EntityManager) initEntity(entity ec.Entity) {
 	ec.UnsafeEntity(entity).SetContext(mgr.ctx)
 
 	event.UnsafeEvent(entity.EventEntityDestroy()).Ctrl().SetPanicHandling(mgr.ctx.AutoRecover(), mgr.ctx.ReportError())
+	event.UnsafeEvent(entity.EventEntityUpdateIntervalChanged()).Ctrl().SetPanicHandling(mgr.ctx.AutoRecover(), mgr.ctx.ReportError())
 
 	event.UnsafeEvent(entity.EventComponentManagerAddComponents()).Ctrl().SetPanicHandling(mgr.ctx.AutoRecover(), mgr.ctx.ReportError())
 	event.UnsafeEvent(entity.EventComponentManagerRemoveComponent()).Ctrl().SetPanicHandling(mgr.ctx.AutoRecover(), mgr.ctx.ReportError())
@@ -292,6 +297,7 @@ func (mgr *_EntityManager) initComponent(entity ec.Entity, comp ec.Component) {
 
 func (mgr *_EntityManager) observeEntity(entity ec.Entity) {
 	ec.BindEventEntityDestroy(entity, mgr)
+	ec.BindEventEntityUpdateIntervalChanged(entity, mgr)
 
 	ec.BindEventComponentManagerAddComponents(entity, mgr)
 	ec.BindEventComponentManagerRemoveComponent(entity, mgr)
@@ -301,3 +301,49 @@ type EventEntityManagerEntityFirstTouchComponentHandler func(entityManager Entit
 func (h EventEntityManagerEntityFirstTouchComponentHandler) OnEntityManagerEntityFirstTouchComponent(entityManager EntityManager, entity ec.Entity, component ec.Component) {
 	h(entityManager, entity, component)
 }
+
+type iAutoEventEntityManagerEntityUpdateIntervalChanged interface {
+	EventEntityManagerEntityUpdateIntervalChanged() event.IEvent
+}
+
+func BindEventEntityManagerEntityUpdateIntervalChanged(auto iAutoEventEntityManagerEntityUpdateIntervalChanged, subscriber EventEntityManagerEntityUpdateIntervalChanged, priority ...int32) event.Handle {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[EventEntityManagerEntityUpdateIntervalChanged](auto.EventEntityManagerEntityUpdateIntervalChanged(), subscriber, priority...)
+}
+
+func _EmitEventEntityManagerEntityUpdateIntervalChanged(auto iAutoEventEntityManagerEntityUpdateIntervalChanged, entityManager EntityManager, entity ec.Entity, interval int64) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityManagerEntityUpdateIntervalChanged()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[EventEntityManagerEntityUpdateIntervalChanged](subscriber).OnEntityManagerEntityUpdateIntervalChanged(entityManager, entity, interval)
+		return true
+	})
+}
+
+func _EmitEventEntityManagerEntityUpdateIntervalChangedWithInterrupt(auto iAutoEventEntityManagerEntityUpdateIntervalChanged, interrupt func(entityManager EntityManager, entity ec.Entity, interval int64) bool, entityManager EntityManager, entity ec.Entity, interval int64) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.EventEntityManagerEntityUpdateIntervalChanged()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(entityManager, entity, interval) {
+				return false
+			}
+		}
+		event.Cache2Iface[EventEntityManagerEntityUpdateIntervalChanged](subscriber).OnEntityManagerEntityUpdateIntervalChanged(entityManager, entity, interval)
+		return true
+	})
+}
+
+func HandleEventEntityManagerEntityUpdateIntervalChanged(fun func(entityManager EntityManager, entity ec.Entity, interval int64)) EventEntityManagerEntityUpdateIntervalChangedHandler {
+	return EventEntityManagerEntityUpdateIntervalChangedHandler(fun)
+}
+
+type EventEntityManagerEntityUpdateIntervalChangedHandler func(entityManager EntityManager, entity ec.Entity, interval int64)
+
+func (h EventEntityManagerEntityUpdateIntervalChangedHandler) OnEntityManagerEntityUpdateIntervalChanged(entityManager EntityManager, entity ec.Entity, interval int64) {
+	h(entityManager, entity, interval)
+}
@@ -70,3 +70,11 @@ type EventEntityManagerEntityComponentEnableChanged interface {
 type EventEntityManagerEntityFirstTouchComponent interface {
 	OnEntityManagerEntityFirstTouchComponent(entityManager EntityManager, entity ec.Entity, component ec.Component)
 }
+
+// EventEntityManagerEntityUpdateIntervalChanged 在受管实体的更新间隔改变后同步派发。
+// Runtime 通过此事件重新绑定实体与组件的帧更新回调。
+// +event-gen:export_emit=0
+// +event-tab-gen:recursion=allow
+type EventEntityManagerEntityUpdateIntervalChanged interface {
+	OnEntityManagerEntityUpdateIntervalChanged(entityManager EntityManager, entity ec.Entity, interval int64)
+}
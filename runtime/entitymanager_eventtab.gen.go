@@ -32,6 +32,7 @@ type IEntityManagerEventTab interface {
 	EventEntityManagerEntityRemoveComponent() event.IEvent
 	EventEntityManagerEntityComponentEnableChanged() event.IEvent
 	EventEntityManagerEntityFirstTouchComponent() event.IEvent
+	EventEntityManagerEntityUpdateIntervalChanged() event.IEvent
 }
 
 var (
@@ -42,9 +43,10 @@ var (
 	EventEntityManagerEntityRemoveComponentID        = event.DeclareEventIDT[entityManagerEventTab](3)
 	EventEntityManagerEntityComponentEnableChangedID = event.DeclareEventIDT[entityManagerEventTab](4)
 	EventEntityManagerEntityFirstTouchComponentID    = event.DeclareEventIDT[entityManagerEventTab](5)
+	EventEntityManagerEntityUpdateIntervalChangedID  = event.DeclareEventIDT[entityManagerEventTab](6)
 )
 
-type entityManagerEventTab [6]event.Event
+type entityManagerEventTab [7]event.Event
 
 func (eventTab *entityManagerEventTab) SetPanicHandling(autoRecover bool, reportError chan error) {
 	for i := range eventTab {
@@ -59,6 +61,7 @@ func (eventTab *entityManagerEventTab) SetRecursion(recursion event.EventRecursi
 	eventTab[3].SetRecursion(event.EventRecursion_Allow)
 	eventTab[4].SetRecursion(event.EventRecursion_Allow)
 	eventTab[5].SetRecursion(event.EventRecursion_Allow)
+	eventTab[6].SetRecursion(event.EventRecursion_Allow)
 }
 
 func (eventTab *entityManagerEventTab) SetEnabled(b bool) {
@@ -95,6 +98,8 @@ func (eventTab *entityManagerEventTab) Event(id uint64) event.IEvent {
 		eventTab[4].SetRecursion(event.EventRecursion_Allow)
 	case 5:
 		eventTab[5].SetRecursion(event.EventRecursion_Allow)
+	case 6:
+		eventTab[6].SetRecursion(event.EventRecursion_Allow)
 	}
 	return &eventTab[pos]
 }
@@ -128,3 +133,8 @@ func (eventTab *entityManagerEventTab) EventEntityManagerEntityFirstTouchCompone
 	eventTab.SetRecursion(event.EventRecursion_Allow)
 	return &eventTab[5]
 }
+
+func (eventTab *entityManagerEventTab) EventEntityManagerEntityUpdateIntervalChanged() event.IEvent {
+	eventTab.SetRecursion(event.EventRecursion_Allow)
+	return &eventTab[6]
+}
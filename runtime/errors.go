@@ -32,4 +32,7 @@ var (
 	ErrFrame                 = fmt.Errorf("%w: frame", ErrContext)                          // 帧循环错误。
 	ErrRuntimeSelfWait       = fmt.Errorf("%w: runtime waits for its own task", ErrContext) // Runtime 等待自身队列结果。
 	ErrBlockingWaitInRuntime = fmt.Errorf("%w: blocking wait in runtime", ErrContext)       // Runtime 内阻塞等待 pending Future。
+	ErrEntityNotAlive        = fmt.Errorf("%w: entity is not alive", ErrContext)            // 目标实体已离开 Alive 状态或不在运行时中。
+	ErrEntityReplaced        = fmt.Errorf("%w: entity id was reused", ErrContext)           // 目标实体的 ID 已被其他实体复用。
+	ErrComponentNotAlive     = fmt.Errorf("%w: component is not alive", ErrContext)         // 目标组件已离开 Alive 状态。
 )
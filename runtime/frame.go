@@ -45,4 +45,27 @@ type Frame interface {
 	UpdateBeginTime() time.Time
 	// LastUpdateElapseTime 返回上一帧更新阶段的耗时。
 	LastUpdateElapseTime() time.Duration
+	// Drift 返回 Realtime 模式下当前帧开始时模拟时间落后于墙钟的时长；其他模式为 0。
+	Drift() time.Duration
+	// SkippedFrames 返回 Realtime 模式下因落后而跳过的帧数。
+	SkippedFrames() int64
+	// DeltaTime 返回当前帧经时间缩放后的模拟时间增量；暂停时为 0。
+	// Manual 模式与固定步长的 Realtime 模式使用名义步长，其他情况使用实测的帧间隔。
+	DeltaTime() time.Duration
+	// SimTime 返回累计的模拟时间，即各帧 DeltaTime 之和。
+	SimTime() time.Duration
+	// TimeScale 返回时间缩放系数。
+	TimeScale() float64
+	// SetTimeScale 设置时间缩放系数，从下一帧开始生效；scale 必须是非负有限值，可在任意 goroutine 调用。
+	SetTimeScale(scale float64)
+	// Pause 暂停模拟时间，帧循环与邮箱继续运行，DeltaTime 为 0；可在任意 goroutine 调用，从下一帧开始生效。
+	Pause()
+	// Resume 恢复模拟时间。
+	Resume()
+	// Paused 返回模拟时间是否已暂停。
+	Paused() bool
+	// FixedDeltaTime 返回 FixedUpdate 的固定步长。
+	FixedDeltaTime() time.Duration
+	// Resimulating 返回当前是否正在回滚后重新模拟；此期间应避免产生外部副作用。
+	Resimulating() bool
 }
@@ -0,0 +1,394 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/meta"
+	"git.golaxy.org/core/utils/option"
+	"git.golaxy.org/tiny/ec"
+	"git.golaxy.org/tiny/utils/id"
+)
+
+var (
+	ErrSnapshot = fmt.Errorf("%w: snapshot", ErrContext) // 快照或恢复失败。
+)
+
+// ComponentCodec 编解码组件状态，供快照保存与恢复组件数据。
+type ComponentCodec interface {
+	// Encode 编码组件状态；返回 nil 表示该组件没有需要保存的状态。
+	Encode(comp ec.Component) ([]byte, error)
+	// Decode 将状态解码到组件。组件可能刚构造、尚未加入运行时，也可能是回滚时仍在运行的组件，
+	// 因此实现须完整覆盖 Encode 保存的状态，不能与组件现有的状态合并。
+	Decode(comp ec.Component, data []byte) error
+}
+
+// JSONComponentCodec 使用 encoding/json 编解码组件实例的导出字段。
+// 解码前会先将 JSON 编码的字段置零，避免 map、切片与指针字段与组件现有的值合并。
+type JSONComponentCodec struct{}
+
+// Encode 将组件实例的导出字段编码为 JSON。
+func (JSONComponentCodec) Encode(comp ec.Component) ([]byte, error) {
+	return json.Marshal(comp.Reflected().Interface())
+}
+
+// Decode 将组件实例中 JSON 编码的字段置零后，再将 JSON 解码到这些字段。
+func (JSONComponentCodec) Decode(comp ec.Component, data []byte) error {
+	v := comp.Reflected()
+	if v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Struct {
+		zeroJSONFields(v.Elem())
+	}
+	return json.Unmarshal(data, v.Interface())
+}
+
+// zeroJSONFields 将结构体中 encoding/json 会编码的字段置零；嵌入的结构体递归处理，不触及未导出字段。
+func zeroJSONFields(v reflect.Value) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if field.Tag.Get("json") == "-" {
+			continue
+		}
+		fv := v.Field(i)
+		if field.Anonymous {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if fv.Kind() == reflect.Pointer {
+					if fv.IsNil() || !field.IsExported() {
+						continue
+					}
+					fv = fv.Elem()
+				}
+				zeroJSONFields(fv)
+				continue
+			}
+		}
+		if !field.IsExported() || !fv.CanSet() {
+			continue
+		}
+		fv.SetZero()
+	}
+}
+
+// FrameSnapshot 保存帧计数、模拟时间与固定步长累积量。
+type FrameSnapshot struct {
+	CurFrames        int64         `json:"cur_frames"`        // 已完成帧数。
+	SimTime          time.Duration `json:"sim_time"`          // 累计模拟时间。
+	TimeScale        float64       `json:"time_scale"`        // 时间缩放系数。
+	Paused           bool          `json:"paused"`            // 模拟时间是否暂停。
+	FixedAccumulator time.Duration `json:"fixed_accumulator"` // 尚未消耗的 FixedUpdate 累积时间。
+}
+
+// FrameRestorer 由支持快照的 Frame 实现。
+type FrameRestorer interface {
+	// SnapshotFrame 返回当前帧计数、模拟时间与固定步长累积量。
+	SnapshotFrame() FrameSnapshot
+	// RestoreFrame 将帧计数、模拟时间与固定步长累积量恢复为 snapshot。
+	RestoreFrame(snapshot FrameSnapshot)
+}
+
+// Snapshot 保存运行时上下文中的实体、组件、实体树关系、ID 生成器与帧计数。
+// 定时器、延迟任务、事件订阅与插件不包含在快照中。
+type Snapshot struct {
+	Name        string             `json:"name"`            // 运行时名称。
+	IDGenerator int64              `json:"id_generator"`    // ID 生成器状态。
+	Frame       *FrameSnapshot     `json:"frame,omitempty"` // 帧状态；未启用帧循环时为 nil。
+	Entities    []EntitySnapshot   `json:"entities"`        // 按加入顺序排列的实体。
+	Tree        []TreeLinkSnapshot `json:"tree"`            // 按先序排列的实体树父子关系。
+}
+
+// EntitySnapshot 保存单个实体。
+type EntitySnapshot struct {
+	ID             id.ID               `json:"id"`              // 实体 ID。
+	Prototype      string              `json:"prototype"`       // 实体原型名。
+	Meta           map[string]any      `json:"meta"`            // 实体元数据。
+	UpdateInterval int64               `json:"update_interval"` // 更新间隔帧数。
+	Components     []ComponentSnapshot `json:"components"`      // 按加入顺序排列的组件。
+}
+
+// ComponentSnapshot 保存单个组件。
+type ComponentSnapshot struct {
+	ID        id.ID  `json:"id"`        // 组件 ID。
+	Name      string `json:"name"`      // 组件在实体中的名称。
+	Prototype string `json:"prototype"` // 组件原型名。
+	Offset    int    `json:"offset"`    // 在实体原型中的位置；动态添加的组件为 -1。
+	Removable bool   `json:"removable"` // 是否允许动态删除。
+	Enabled   bool   `json:"enabled"`   // 是否启用。
+	Data      []byte `json:"data"`      // ComponentCodec 编码的组件状态。
+}
+
+// TreeLinkSnapshot 保存一条实体树父子关系；根节点的 Parent 为 ForestNodeID。
+type TreeLinkSnapshot struct {
+	Parent id.ID `json:"parent"` // 父实体 ID。
+	Child  id.ID `json:"child"`  // 子实体 ID。
+}
+
+// Snapshot 捕获运行时上下文的当前状态，组件数据通过 codec 编码；codec 为 nil 时不保存组件数据。
+// 已进入 Leaving 及后续状态的实体与已进入 Detaching 及后续状态的组件不会被保存。
+func (ctx *ContextBehavior) Snapshot(codec ComponentCodec) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		Name:        ctx.Name(),
+		IDGenerator: ctx.idGenerator,
+	}
+
+	if restorer, ok := ctx.frame.(FrameRestorer); ok {
+		frame := restorer.SnapshotFrame()
+		snapshot.Frame = &frame
+	}
+
+	var err error
+	ctx.entityManager.RangeEntities(func(entity ec.Entity) bool {
+		if entity.State() > ec.EntityState_Alive {
+			return true
+		}
+		var entitySnapshot EntitySnapshot
+		entitySnapshot, err = snapshotEntity(entity, codec)
+		if err != nil {
+			return false
+		}
+		snapshot.Entities = append(snapshot.Entities, entitySnapshot)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot.Tree = ctx.snapshotTree(ForestNodeID, snapshot.Tree)
+
+	return snapshot, nil
+}
+
+// Restore 将快照恢复到尚未持有实体的运行时上下文：按原型名从 EntityLib 重建实体与组件，
+// 在实体加入运行时之前通过 codec 解码组件数据，再重建实体树并恢复 ID 生成器与帧计数。
+// 恢复失败时已经加入的实体不会回滚。
+func (ctx *ContextBehavior) Restore(snapshot *Snapshot, codec ComponentCodec) error {
+	if snapshot == nil {
+		exception.Panicf("%w: %w: snapshot is nil", ErrSnapshot, exception.ErrArgs)
+	}
+
+	if ctx.entityManager.CountEntities() > 0 {
+		return fmt.Errorf("%w: context already holds entities", ErrSnapshot)
+	}
+
+	entities := make([]ec.Entity, 0, len(snapshot.Entities))
+	for i := range snapshot.Entities {
+		entity, err := ctx.restoreEntity(&snapshot.Entities[i], codec)
+		if err != nil {
+			return err
+		}
+		entities = append(entities, entity)
+	}
+
+	ctx.idGenerator = max(ctx.idGenerator, snapshot.IDGenerator)
+
+	for _, entity := range entities {
+		if err := ctx.entityManager.AddEntity(entity); err != nil {
+			return fmt.Errorf("%w: %w", ErrSnapshot, err)
+		}
+	}
+
+	for _, link := range snapshot.Tree {
+		if err := ctx.entityManager.AddChild(link.Parent, link.Child); err != nil {
+			return fmt.Errorf("%w: %w", ErrSnapshot, err)
+		}
+	}
+
+	if snapshot.Frame != nil {
+		if restorer, ok := ctx.frame.(FrameRestorer); ok {
+			restorer.RestoreFrame(*snapshot.Frame)
+		}
+	}
+
+	return nil
+}
+
+// SnapshotEntity 捕获单个存活实体及其组件，不包含实体树关系。
+func (ctx *ContextBehavior) SnapshotEntity(entityID id.ID, codec ComponentCodec) (*EntitySnapshot, error) {
+	entity, ok := ctx.entityManager.GetEntity(entityID)
+	if !ok || entity.State() > ec.EntityState_Alive {
+		return nil, fmt.Errorf("%w: entity %q not found", ErrSnapshot, entityID)
+	}
+
+	entitySnapshot, err := snapshotEntity(entity, codec)
+	if err != nil {
+		return nil, err
+	}
+	return &entitySnapshot, nil
+}
+
+// RestoreEntity 按原型名从 EntityLib 重建实体并加入运行时，快照 ID 为空时由运行时分配新 ID。
+// 加入失败时实体不会留在运行时中。
+func (ctx *ContextBehavior) RestoreEntity(entitySnapshot *EntitySnapshot, codec ComponentCodec) (ec.Entity, error) {
+	if entitySnapshot == nil {
+		exception.Panicf("%w: %w: entitySnapshot is nil", ErrSnapshot, exception.ErrArgs)
+	}
+
+	entity, err := ctx.restoreEntity(entitySnapshot, codec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.entityManager.AddEntity(entity); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSnapshot, err)
+	}
+
+	return entity, nil
+}
+
+// ValidateEntity 按原型名从 EntityLib 重建实体并通过 codec 解码组件数据，但不加入运行时，
+// 用于在移除源实体前确认快照能在当前运行时恢复。
+func (ctx *ContextBehavior) ValidateEntity(entitySnapshot *EntitySnapshot, codec ComponentCodec) error {
+	if entitySnapshot == nil {
+		exception.Panicf("%w: %w: entitySnapshot is nil", ErrSnapshot, exception.ErrArgs)
+	}
+
+	_, err := ctx.restoreEntity(entitySnapshot, codec)
+	return err
+}
+
+func (ctx *ContextBehavior) snapshotTree(parentID id.ID, links []TreeLinkSnapshot) []TreeLinkSnapshot {
+	ctx.entityManager.EachChildren(parentID, func(child ec.Entity) {
+		if child.State() > ec.EntityState_Alive {
+			return
+		}
+		links = append(links, TreeLinkSnapshot{Parent: parentID, Child: child.ID()})
+		links = ctx.snapshotTree(child.ID(), links)
+	})
+	return links
+}
+
+func (ctx *ContextBehavior) restoreEntity(entitySnapshot *EntitySnapshot, codec ComponentCodec) (ec.Entity, error) {
+	entityPT, ok := ctx.EntityLib().Get(entitySnapshot.Prototype)
+	if !ok {
+		return nil, fmt.Errorf("%w: entity %q was not declared", ErrSnapshot, entitySnapshot.Prototype)
+	}
+
+	settings := []option.Setting[ec.EntityOptions]{
+		ec.With.ID(entitySnapshot.ID),
+		ec.With.Meta(meta.New(entitySnapshot.Meta)),
+	}
+	if entitySnapshot.UpdateInterval > 0 {
+		settings = append(settings, ec.With.UpdateInterval(entitySnapshot.UpdateInterval))
+	}
+
+	entity := entityPT.Construct(settings...)
+
+	builtins := map[int]ec.Component{}
+	entity.EachComponents(func(comp ec.Component) {
+		if offset := comp.Builtin().Offset; offset >= 0 {
+			builtins[offset] = comp
+		}
+	})
+
+	for i := range entitySnapshot.Components {
+		compSnapshot := &entitySnapshot.Components[i]
+
+		var comp ec.Component
+
+		if compSnapshot.Offset >= 0 {
+			comp, ok = builtins[compSnapshot.Offset]
+			if !ok {
+				return nil, fmt.Errorf("%w: entity %q has no builtin component at offset %d", ErrSnapshot, entitySnapshot.Prototype, compSnapshot.Offset)
+			}
+			delete(builtins, compSnapshot.Offset)
+		} else {
+			compPT, ok := ctx.EntityLib().ComponentLib().Get(compSnapshot.Prototype)
+			if !ok {
+				return nil, fmt.Errorf("%w: component %q was not declared", ErrSnapshot, compSnapshot.Prototype)
+			}
+			comp = compPT.Construct()
+			ec.UnsafeComponent(comp).SetRemovable(compSnapshot.Removable)
+			if err := entity.AddComponent(compSnapshot.Name, comp); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrSnapshot, err)
+			}
+		}
+
+		if ec.UnsafeEntity(entity).Options().ComponentUniqueID {
+			ec.UnsafeComponent(comp).SetID(compSnapshot.ID)
+		}
+
+		if !compSnapshot.Enabled {
+			comp.SetEnabled(false)
+		}
+
+		if codec != nil && compSnapshot.Data != nil {
+			if err := codec.Decode(comp, compSnapshot.Data); err != nil {
+				return nil, fmt.Errorf("%w: decode component %q: %w", ErrSnapshot, compSnapshot.Name, err)
+			}
+		}
+	}
+
+	// 快照中不存在的内建组件在捕获前已被删除
+	for _, comp := range builtins {
+		comp.Destroy()
+	}
+
+	return entity, nil
+}
+
+func snapshotEntity(entity ec.Entity, codec ComponentCodec) (EntitySnapshot, error) {
+	entitySnapshot := EntitySnapshot{
+		ID:             entity.ID(),
+		Prototype:      entity.PT().Prototype(),
+		Meta:           entity.Meta().ToGoMap(),
+		UpdateInterval: entity.UpdateInterval(),
+	}
+
+	var err error
+	entity.RangeComponents(func(comp ec.Component) bool {
+		if comp.State() > ec.ComponentState_Alive {
+			return true
+		}
+
+		builtin := comp.Builtin()
+
+		compSnapshot := ComponentSnapshot{
+			ID:        comp.ID(),
+			Name:      comp.Name(),
+			Offset:    builtin.Offset,
+			Removable: comp.Removable(),
+			Enabled:   comp.Enabled(),
+		}
+		if builtin.PT != nil {
+			compSnapshot.Prototype = builtin.PT.Prototype()
+		}
+
+		if codec != nil {
+			compSnapshot.Data, err = codec.Encode(comp)
+			if err != nil {
+				err = fmt.Errorf("%w: encode component %q: %w", ErrSnapshot, comp.Name(), err)
+				return false
+			}
+		}
+
+		entitySnapshot.Components = append(entitySnapshot.Components, compSnapshot)
+		return true
+	})
+
+	return entitySnapshot, err
+}
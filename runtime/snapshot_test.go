@@ -0,0 +1,150 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/tiny"
+	"git.golaxy.org/tiny/ec"
+	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/id"
+)
+
+type snapshotCounter struct {
+	ec.ComponentBehavior
+	N int
+}
+
+func newSnapshotRuntime(t *testing.T, name string) (runtime.Context, tiny.Runtime) {
+	t.Helper()
+
+	rtCtx := runtime.NewContext(runtime.With.Name(name))
+	tiny.BuildEntityPT(rtCtx, "unit").
+		AddComponent(&snapshotCounter{}, "counter").
+		Declare()
+
+	rt := tiny.NewRuntime(rtCtx, tiny.With.Runtime.Frame(
+		tiny.With.Frame.Mode(tiny.FrameMode_Manual),
+	))
+
+	terminated := rt.Run()
+	t.Cleanup(func() {
+		rt.Terminate()
+		waitCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		if err := terminated.Wait(waitCtx); err != nil {
+			t.Errorf("terminate %s: %v", name, err)
+		}
+	})
+
+	return rtCtx, rt
+}
+
+func submitSnapshotTask(t *testing.T, rt tiny.Runtime, fun func(ctx runtime.Context) (any, error)) any {
+	t.Helper()
+
+	waitCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	ret := rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		return async.NewResult(fun(ctx))
+	}).Wait(waitCtx)
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+	return ret.Value
+}
+
+func TestSnapshotRestoreRoundTrip(t *testing.T) {
+	_, src := newSnapshotRuntime(t, "src")
+
+	waitCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if ret := src.AdvanceFrames(3).Wait(waitCtx); ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+
+	ids := submitSnapshotTask(t, src, func(ctx runtime.Context) (any, error) {
+		parent, err := tiny.BuildEntity(ctx, "unit").New()
+		if err != nil {
+			return nil, err
+		}
+		child, err := tiny.BuildEntity(ctx, "unit").New()
+		if err != nil {
+			return nil, err
+		}
+		if err := ctx.EntityTree().AddChild(parent.ID(), child.ID()); err != nil {
+			return nil, err
+		}
+		parent.GetComponent("counter").(*snapshotCounter).N = 7
+		child.GetComponent("counter").(*snapshotCounter).N = 11
+		return [2]id.ID{parent.ID(), child.ID()}, nil
+	}).([2]id.ID)
+
+	snapshot := submitSnapshotTask(t, src, func(ctx runtime.Context) (any, error) {
+		return ctx.Snapshot(runtime.JSONComponentCodec{})
+	}).(*runtime.Snapshot)
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded runtime.Snapshot
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	_, dst := newSnapshotRuntime(t, "dst")
+
+	submitSnapshotTask(t, dst, func(ctx runtime.Context) (any, error) {
+		if err := ctx.Restore(&decoded, runtime.JSONComponentCodec{}); err != nil {
+			return nil, err
+		}
+
+		for i, want := range []int{7, 11} {
+			entity, ok := ctx.EntityManager().GetEntity(ids[i])
+			if !ok {
+				t.Errorf("entity %q not restored", ids[i])
+				continue
+			}
+			if got := entity.GetComponent("counter").(*snapshotCounter).N; got != want {
+				t.Errorf("entity %q counter: got %d, want %d", ids[i], got, want)
+			}
+		}
+
+		parent, err := ctx.EntityTree().GetParent(ids[1])
+		if err != nil {
+			return nil, err
+		}
+		if parent.ID() != ids[0] {
+			t.Errorf("child parent: got %q, want %q", parent.ID(), ids[0])
+		}
+
+		if got := ctx.Frame().(runtime.FrameRestorer).SnapshotFrame(); got != *snapshot.Frame {
+			t.Errorf("frame: got %+v, want %+v", got, *snapshot.Frame)
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,30 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+import "git.golaxy.org/core/utils/async"
+
+// TaskHandle 引用已进入 Runtime 邮箱的任务，可在任务开始执行前撤回。
+type TaskHandle interface {
+	// Cancel 撤回尚未开始执行的任务；任务已开始、已结束或已撤回时返回 false。
+	Cancel() bool
+	// Future 返回任务结果 Future；Post 任务返回空 Future。
+	Future() async.Future
+}
@@ -0,0 +1,30 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+// TaskPriority 标识任务进入 Runtime 邮箱时使用的优先级通道。
+// 仅在 Runtime 启用优先级通道时生效；未启用时所有任务共用同一队列。
+type TaskPriority int8
+
+const (
+	TaskPriority_High   TaskPriority = iota // 高优先级，适合玩家指令、停机请求等紧急任务；Realtime 帧任务也使用该通道。
+	TaskPriority_Normal                     // 普通优先级，普通 Submit/Post 使用的默认通道。
+	TaskPriority_Low                        // 低优先级，适合聊天、遥测等可延后的任务。
+)
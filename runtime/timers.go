@@ -0,0 +1,428 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+import (
+	"time"
+
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/tiny/ec"
+)
+
+// Timers 提供运行时本地的分层时间轮定时器，回调在 Runtime goroutine 中随帧循环执行。
+// 时间定时器按 Frame.SimTime 推进（未启用帧循环时按运行时长），帧定时器按已完成帧数推进；
+// 所有方法只能在 Runtime goroutine 中调用。
+type Timers interface {
+	// After 在 dur 后执行一次 fun。
+	After(dur time.Duration, fun generic.Action1[Context]) Timer
+	// Every 每隔 interval 执行一次 fun，直到取消。
+	Every(interval time.Duration, fun generic.Action1[Context]) Timer
+	// AfterFrames 在再完成 frames 帧后执行一次 fun；未启用帧循环时会 panic。
+	AfterFrames(frames int64, fun generic.Action1[Context]) Timer
+	// EveryFrames 每完成 frames 帧执行一次 fun，直到取消；未启用帧循环时会 panic。
+	EveryFrames(frames int64, fun generic.Action1[Context]) Timer
+	// ForEntity 返回绑定实体生命周期的定时器集合，实体进入 Dead 后其定时器自动取消。
+	ForEntity(entity ec.Entity) Timers
+	// ForComponent 返回绑定组件生命周期的定时器集合，组件进入 Dead 后其定时器自动取消。
+	ForComponent(comp ec.Component) Timers
+}
+
+// Timer 引用一个已创建的定时器。
+type Timer interface {
+	// Cancel 取消定时器；定时器已触发完毕或已取消时返回 false。
+	Cancel() bool
+	// Active 返回定时器是否仍在等待触发。
+	Active() bool
+}
+
+const (
+	timerTick        = time.Millisecond                                // 时间轮的最小刻度。
+	timerWheelBits   = 6                                               // 每层槽位数的位宽。
+	timerWheelSlots  = 1 << timerWheelBits                             // 每层槽位数。
+	timerWheelMask   = timerWheelSlots - 1                             // 槽位掩码。
+	timerWheelLevels = 5                                               // 时间轮层数。
+	timerWheelSpan   = int64(1) << (timerWheelBits * timerWheelLevels) // 时间轮可直接容纳的刻度跨度。
+)
+
+type _Timer struct {
+	timers     *_Timers
+	wheel      *_TimerWheel
+	owner      any
+	fun        generic.Action1[Context]
+	expire     int64
+	period     int64
+	slot       *_TimerList
+	prev, next *_Timer
+}
+
+// Cancel 取消定时器；定时器已触发完毕或已取消时返回 false。
+func (t *_Timer) Cancel() bool {
+	if t.slot == nil {
+		return false
+	}
+	t.wheel.remove(t)
+	t.timers.untrack(t)
+	return true
+}
+
+// Active 返回定时器是否仍在等待触发。
+func (t *_Timer) Active() bool {
+	return t.slot != nil
+}
+
+type _TimerList struct {
+	head, tail *_Timer
+}
+
+func (l *_TimerList) push(t *_Timer) {
+	t.slot = l
+	t.prev = l.tail
+	t.next = nil
+	if l.tail != nil {
+		l.tail.next = t
+	} else {
+		l.head = t
+	}
+	l.tail = t
+}
+
+func (l *_TimerList) remove(t *_Timer) {
+	if t.prev != nil {
+		t.prev.next = t.next
+	} else {
+		l.head = t.next
+	}
+	if t.next != nil {
+		t.next.prev = t.prev
+	} else {
+		l.tail = t.prev
+	}
+	t.slot = nil
+	t.prev = nil
+	t.next = nil
+}
+
+// move 将 l 中的全部定时器转移到 dst。
+func (l *_TimerList) move(dst *_TimerList) {
+	for t := l.head; t != nil; t = t.next {
+		t.slot = dst
+	}
+	*dst = *l
+	*l = _TimerList{}
+}
+
+// _TimerWheel 是分层时间轮，每层 timerWheelSlots 个槽位，高层槽位到期时逐级下沉。
+type _TimerWheel struct {
+	slots [timerWheelLevels][timerWheelSlots]_TimerList
+	cur   int64
+	count int
+}
+
+func (w *_TimerWheel) add(t *_Timer) {
+	if t.expire <= w.cur {
+		t.expire = w.cur + 1
+	}
+
+	expire := t.expire
+	if expire-w.cur >= timerWheelSpan {
+		expire = w.cur + timerWheelSpan - 1
+	}
+
+	level := 0
+	for level < timerWheelLevels-1 && expire-w.cur >= int64(1)<<(timerWheelBits*(level+1)) {
+		level++
+	}
+
+	t.wheel = w
+	w.slots[level][(expire>>(timerWheelBits*level))&timerWheelMask].push(t)
+	w.count++
+}
+
+func (w *_TimerWheel) remove(t *_Timer) {
+	t.slot.remove(t)
+	w.count--
+}
+
+// advance 将时间轮推进到 to，按刻度依次触发到期的定时器。
+func (w *_TimerWheel) advance(to int64, fire func(t *_Timer)) {
+	for w.cur < to {
+		if w.count <= 0 {
+			w.cur = to
+			return
+		}
+
+		w.cur++
+
+		for level := 1; level < timerWheelLevels; level++ {
+			if (w.cur>>(timerWheelBits*(level-1)))&timerWheelMask != 0 {
+				break
+			}
+			w.cascade(&w.slots[level][(w.cur>>(timerWheelBits*level))&timerWheelMask])
+		}
+
+		var due _TimerList
+		w.slots[0][w.cur&timerWheelMask].move(&due)
+
+		for due.head != nil {
+			t := due.head
+			due.remove(t)
+			w.count--
+
+			if t.expire > w.cur {
+				w.add(t)
+				continue
+			}
+
+			fire(t)
+		}
+	}
+}
+
+func (w *_TimerWheel) cascade(slot *_TimerList) {
+	var list _TimerList
+	slot.move(&list)
+
+	for list.head != nil {
+		t := list.head
+		list.remove(t)
+		w.count--
+		w.add(t)
+	}
+}
+
+func (w *_TimerWheel) clear() {
+	for level := range w.slots {
+		for i := range w.slots[level] {
+			slot := &w.slots[level][i]
+			for slot.head != nil {
+				slot.remove(slot.head)
+			}
+		}
+	}
+	w.count = 0
+}
+
+// _Timers 维护运行时上下文的时间定时器与帧定时器。
+type _Timers struct {
+	ctx    Context
+	time   _TimerWheel
+	frames _TimerWheel
+	owners map[any]map[*_Timer]struct{}
+}
+
+func (ts *_Timers) init(ctx Context) {
+	ts.ctx = ctx
+}
+
+func (ts *_Timers) scope(owner any) Timers {
+	return _TimerScope{timers: ts, owner: owner}
+}
+
+func (ts *_Timers) add(wheel *_TimerWheel, owner any, delay, period int64, fun generic.Action1[Context]) Timer {
+	if fun == nil {
+		exception.Panicf("%w: %w: fun is nil", ErrContext, exception.ErrArgs)
+	}
+
+	t := &_Timer{
+		timers: ts,
+		owner:  owner,
+		fun:    fun,
+		expire: wheel.cur + delay,
+		period: period,
+	}
+
+	if owner != nil && timerOwnerDead(owner) {
+		return t
+	}
+
+	wheel.add(t)
+	ts.track(t)
+
+	return t
+}
+
+func (ts *_Timers) advance(elapsed time.Duration, frames int64) {
+	ts.time.advance(int64(elapsed/timerTick), ts.fire)
+	ts.frames.advance(frames, ts.fire)
+}
+
+func (ts *_Timers) count() int {
+	return ts.time.count + ts.frames.count
+}
+
+func (ts *_Timers) fire(t *_Timer) {
+	if t.owner != nil && timerOwnerDead(t.owner) {
+		ts.untrack(t)
+		return
+	}
+
+	if t.period > 0 {
+		t.expire += t.period
+		t.wheel.add(t)
+	} else {
+		ts.untrack(t)
+	}
+
+	t.fun.Call(ts.ctx.AutoRecover(), ts.ctx.ReportError(), ts.ctx)
+}
+
+func (ts *_Timers) track(t *_Timer) {
+	if t.owner == nil {
+		return
+	}
+	if ts.owners == nil {
+		ts.owners = map[any]map[*_Timer]struct{}{}
+	}
+	owned, ok := ts.owners[t.owner]
+	if !ok {
+		owned = map[*_Timer]struct{}{}
+		ts.owners[t.owner] = owned
+	}
+	owned[t] = struct{}{}
+}
+
+func (ts *_Timers) untrack(t *_Timer) {
+	if t.owner == nil {
+		return
+	}
+	owned, ok := ts.owners[t.owner]
+	if !ok {
+		return
+	}
+	delete(owned, t)
+	if len(owned) <= 0 {
+		delete(ts.owners, t.owner)
+	}
+}
+
+// cancelOwner 取消绑定 owner 生命周期的全部定时器。
+func (ts *_Timers) cancelOwner(owner any) {
+	owned, ok := ts.owners[owner]
+	if !ok {
+		return
+	}
+	for t := range owned {
+		if t.slot != nil {
+			t.wheel.remove(t)
+		}
+	}
+	delete(ts.owners, owner)
+}
+
+func (ts *_Timers) onContextRunningEvent(ctx Context, runningEvent RunningEvent, args ...any) {
+	switch runningEvent {
+	case RunningEvent_EntityDeactivated:
+		if len(ts.owners) <= 0 {
+			return
+		}
+		entity := args[0].(ec.Entity)
+		ts.cancelOwner(entity)
+		ec.UnsafeEntity(entity).ComponentList().TraversalEach(func(slot *generic.FreeSlot[ec.Component]) {
+			ts.cancelOwner(slot.V)
+		})
+	case RunningEvent_EntityComponentDeactivated:
+		if len(ts.owners) <= 0 {
+			return
+		}
+		ts.cancelOwner(args[1].(ec.Component))
+	case RunningEvent_Terminated:
+		ts.time.clear()
+		ts.frames.clear()
+		ts.owners = nil
+	}
+}
+
+func timerOwnerDead(owner any) bool {
+	switch owner := owner.(type) {
+	case ec.Entity:
+		return owner.State() >= ec.EntityState_Dead
+	case ec.Component:
+		return owner.State() >= ec.ComponentState_Dead
+	}
+	return false
+}
+
+func durationTicks(dur time.Duration) int64 {
+	return int64((dur + timerTick - 1) / timerTick)
+}
+
+// _TimerScope 是绑定可选生命周期所有者的定时器集合视图。
+type _TimerScope struct {
+	timers *_Timers
+	owner  any
+}
+
+// After 在 dur 后执行一次 fun。
+func (s _TimerScope) After(dur time.Duration, fun generic.Action1[Context]) Timer {
+	if dur < 0 {
+		exception.Panicf("%w: %w: dur must be greater than or equal to 0", ErrContext, exception.ErrArgs)
+	}
+	return s.timers.add(&s.timers.time, s.owner, durationTicks(dur), 0, fun)
+}
+
+// Every 每隔 interval 执行一次 fun，直到取消。
+func (s _TimerScope) Every(interval time.Duration, fun generic.Action1[Context]) Timer {
+	if interval <= 0 {
+		exception.Panicf("%w: %w: interval must be greater than 0", ErrContext, exception.ErrArgs)
+	}
+	ticks := durationTicks(interval)
+	return s.timers.add(&s.timers.time, s.owner, ticks, ticks, fun)
+}
+
+// AfterFrames 在再完成 frames 帧后执行一次 fun；frames 为 0 时在下一帧执行。
+func (s _TimerScope) AfterFrames(frames int64, fun generic.Action1[Context]) Timer {
+	s.checkFrame()
+	if frames < 0 {
+		exception.Panicf("%w: %w: frames must be greater than or equal to 0", ErrContext, exception.ErrArgs)
+	}
+	return s.timers.add(&s.timers.frames, s.owner, frames, 0, fun)
+}
+
+// EveryFrames 每完成 frames 帧执行一次 fun，直到取消。
+func (s _TimerScope) EveryFrames(frames int64, fun generic.Action1[Context]) Timer {
+	s.checkFrame()
+	if frames <= 0 {
+		exception.Panicf("%w: %w: frames must be greater than 0", ErrContext, exception.ErrArgs)
+	}
+	return s.timers.add(&s.timers.frames, s.owner, frames, frames, fun)
+}
+
+// ForEntity 返回绑定实体生命周期的定时器集合。
+func (s _TimerScope) ForEntity(entity ec.Entity) Timers {
+	if entity == nil {
+		exception.Panicf("%w: %w: entity is nil", ErrContext, exception.ErrArgs)
+	}
+	return s.timers.scope(entity)
+}
+
+// ForComponent 返回绑定组件生命周期的定时器集合。
+func (s _TimerScope) ForComponent(comp ec.Component) Timers {
+	if comp == nil {
+		exception.Panicf("%w: %w: comp is nil", ErrContext, exception.ErrArgs)
+	}
+	return s.timers.scope(comp)
+}
+
+func (s _TimerScope) checkFrame() {
+	if s.timers.ctx.Frame() == nil {
+		exception.Panicf("%w: frame loop is disabled", ErrFrame)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package runtime
+
+import "testing"
+
+func TestTimerWheelCascade(t *testing.T) {
+	var wheel _TimerWheel
+
+	expires := []int64{1, timerWheelSlots, timerWheelSlots + 1, 5000, timerWheelSlots * timerWheelSlots * 3, 1 << 20}
+	for _, expire := range expires {
+		wheel.add(&_Timer{expire: expire})
+	}
+
+	var fired []int64
+	fire := func(timer *_Timer) {
+		if timer.expire != wheel.cur {
+			t.Fatalf("timer expiring at %d fired at %d", timer.expire, wheel.cur)
+		}
+		fired = append(fired, timer.expire)
+	}
+
+	for _, expire := range expires {
+		wheel.advance(expire, fire)
+		if len(fired) == 0 || fired[len(fired)-1] != expire {
+			t.Fatalf("timer expiring at %d not fired, fired %v", expire, fired)
+		}
+	}
+
+	if wheel.count != 0 {
+		t.Fatalf("wheel count after firing all timers: got %d, want 0", wheel.count)
+	}
+}
@@ -19,7 +19,10 @@
 
 package runtime
 
-import "sync/atomic"
+import (
+	"sync/atomic"
+	"time"
+)
 
 // Deprecated: UnsafeContext 暴露运行时上下文内部能力，仅供框架集成代码使用。
 func UnsafeContext(ctx Context) _UnsafeContext {
@@ -67,6 +70,16 @@ func (u _UnsafeContext) Scoped() *atomic.Bool {
 	return u.getScoped()
 }
 
+// AdvanceTimers 将定时器推进到运行时长 elapsed 与已完成帧数 frames，并执行到期回调。
+func (u _UnsafeContext) AdvanceTimers(elapsed time.Duration, frames int64) {
+	u.advanceTimers(elapsed, frames)
+}
+
+// CountTimers 返回等待触发的定时器数量。
+func (u _UnsafeContext) CountTimers() int {
+	return u.countTimers()
+}
+
 // GC 清理当前运行时收集的对象。
 func (u _UnsafeContext) GC() {
 	u.gc()
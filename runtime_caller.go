@@ -20,31 +20,105 @@
 package tiny
 
 import (
+	"context"
+
 	"git.golaxy.org/core/utils/async"
 	"git.golaxy.org/core/utils/generic"
 	"git.golaxy.org/tiny/runtime"
 )
 
 func (rt *RuntimeBehavior) Submit(fun generic.FuncVar1[runtime.Context, any, async.Result], args ...any) async.Future {
-	return rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), fun, nil, nil, nil, args)
+	return rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), _Task{lane: runtime.TaskPriority_Normal, fun: fun, args: args}, nil)
 }
 
 func (rt *RuntimeBehavior) SubmitDelegate(fun generic.DelegateVar1[runtime.Context, any, async.Result], args ...any) async.Future {
-	return rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), nil, nil, fun, nil, args)
+	return rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), _Task{lane: runtime.TaskPriority_Normal, delegate: fun, args: args}, nil)
 }
 
 func (rt *RuntimeBehavior) SubmitVoid(fun generic.ActionVar1[runtime.Context, any], args ...any) async.Future {
-	return rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), nil, fun, nil, nil, args)
+	return rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), _Task{lane: runtime.TaskPriority_Normal, action: fun, args: args}, nil)
 }
 
 func (rt *RuntimeBehavior) SubmitDelegateVoid(fun generic.DelegateVoidVar1[runtime.Context, any], args ...any) async.Future {
-	return rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), nil, nil, nil, fun, args)
+	return rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), _Task{lane: runtime.TaskPriority_Normal, delegateVoid: fun, args: args}, nil)
 }
 
 func (rt *RuntimeBehavior) Post(fun generic.ActionVar1[runtime.Context, any], args ...any) error {
-	return rt.taskQueue.enqueuePost(fun, nil, args)
+	return rt.taskQueue.enqueuePost(_Task{lane: runtime.TaskPriority_Normal, action: fun, args: args}, nil)
 }
 
 func (rt *RuntimeBehavior) PostDelegate(fun generic.DelegateVoidVar1[runtime.Context, any], args ...any) error {
-	return rt.taskQueue.enqueuePost(nil, fun, args)
+	return rt.taskQueue.enqueuePost(_Task{lane: runtime.TaskPriority_Normal, delegateVoid: fun, args: args}, nil)
+}
+
+func (rt *RuntimeBehavior) SubmitPriority(priority runtime.TaskPriority, fun generic.FuncVar1[runtime.Context, any, async.Result], args ...any) async.Future {
+	if err := checkTaskPriority(priority); err != nil {
+		return async.Rejected(err)
+	}
+	return rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), _Task{lane: priority, fun: fun, args: args}, nil)
+}
+
+func (rt *RuntimeBehavior) SubmitVoidPriority(priority runtime.TaskPriority, fun generic.ActionVar1[runtime.Context, any], args ...any) async.Future {
+	if err := checkTaskPriority(priority); err != nil {
+		return async.Rejected(err)
+	}
+	return rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), _Task{lane: priority, action: fun, args: args}, nil)
+}
+
+func (rt *RuntimeBehavior) PostPriority(priority runtime.TaskPriority, fun generic.ActionVar1[runtime.Context, any], args ...any) error {
+	if err := checkTaskPriority(priority); err != nil {
+		return err
+	}
+	return rt.taskQueue.enqueuePost(_Task{lane: priority, action: fun, args: args}, nil)
+}
+
+func (rt *RuntimeBehavior) SubmitCtx(ctx context.Context, fun generic.FuncVar1[runtime.Context, any, async.Result], args ...any) async.Future {
+	return rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), _Task{lane: runtime.TaskPriority_Normal, ctx: ctx, fun: fun, args: args}, nil)
+}
+
+func (rt *RuntimeBehavior) SubmitVoidCtx(ctx context.Context, fun generic.ActionVar1[runtime.Context, any], args ...any) async.Future {
+	return rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), _Task{lane: runtime.TaskPriority_Normal, ctx: ctx, action: fun, args: args}, nil)
+}
+
+func (rt *RuntimeBehavior) SubmitWait(ctx context.Context, fun generic.FuncVar1[runtime.Context, any, async.Result], args ...any) async.Future {
+	return rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), _Task{lane: runtime.TaskPriority_Normal, ctx: ctx, fun: fun, args: args}, waitContext(ctx))
+}
+
+func (rt *RuntimeBehavior) SubmitVoidWait(ctx context.Context, fun generic.ActionVar1[runtime.Context, any], args ...any) async.Future {
+	return rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), _Task{lane: runtime.TaskPriority_Normal, ctx: ctx, action: fun, args: args}, waitContext(ctx))
+}
+
+func (rt *RuntimeBehavior) PostWait(ctx context.Context, fun generic.ActionVar1[runtime.Context, any], args ...any) error {
+	return rt.taskQueue.enqueuePost(_Task{lane: runtime.TaskPriority_Normal, action: fun, args: args}, waitContext(ctx))
+}
+
+func (rt *RuntimeBehavior) PostKeyed(key any, fun generic.ActionVar1[runtime.Context, any], args ...any) error {
+	return rt.taskQueue.enqueuePostKeyed(key, _Task{lane: runtime.TaskPriority_Normal, action: fun, args: args})
+}
+
+func (rt *RuntimeBehavior) SubmitCancelable(fun generic.FuncVar1[runtime.Context, any, async.Result], args ...any) runtime.TaskHandle {
+	guard := &_TaskGuard{}
+	future := rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), _Task{lane: runtime.TaskPriority_Normal, guard: guard, fun: fun, args: args}, nil)
+	return &_TaskHandle{guard: guard, future: future}
+}
+
+func (rt *RuntimeBehavior) SubmitVoidCancelable(fun generic.ActionVar1[runtime.Context, any], args ...any) runtime.TaskHandle {
+	guard := &_TaskGuard{}
+	future := rt.taskQueue.enqueueSubmit(rt.ctx.ExecutorID(), _Task{lane: runtime.TaskPriority_Normal, guard: guard, action: fun, args: args}, nil)
+	return &_TaskHandle{guard: guard, future: future}
+}
+
+func (rt *RuntimeBehavior) PostCancelable(fun generic.ActionVar1[runtime.Context, any], args ...any) (runtime.TaskHandle, error) {
+	guard := &_TaskGuard{}
+	if err := rt.taskQueue.enqueuePost(_Task{lane: runtime.TaskPriority_Normal, guard: guard, action: fun, args: args}, nil); err != nil {
+		return nil, err
+	}
+	return &_TaskHandle{guard: guard}, nil
+}
+
+func waitContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
 }
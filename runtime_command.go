@@ -0,0 +1,190 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/tiny/runtime"
+)
+
+var (
+	ErrCommandNotFound = fmt.Errorf("%w: command not found", ErrRuntime)        // 命令未注册。
+	ErrCommandExpired  = fmt.Errorf("%w: command frame has passed", ErrRuntime) // 回放时命令所在帧已经执行过。
+)
+
+type (
+	CommandHandler = generic.Func1[CommandCall, async.Result] // 命令处理函数，在 Runtime goroutine 中执行。
+)
+
+// Command 是一条可序列化的命令记录。
+type Command struct {
+	Frame int64           `json:"frame"`          // 命令执行时的帧号，即 Frame.CurFrames；未启用帧循环时为 0。
+	Seq   int64           `json:"seq"`            // 命令在本运行时中的执行序号。
+	Name  string          `json:"name"`           // 命令名称。
+	Args  json.RawMessage `json:"args,omitempty"` // 命令参数的 JSON 编码。
+}
+
+// CommandCall 是命令处理函数的调用参数。
+type CommandCall struct {
+	Context runtime.Context // 运行时上下文。
+	Command Command         // 当前执行的命令。
+}
+
+// Decode 将命令参数解码到 v。
+func (call CommandCall) Decode(v any) error {
+	if len(call.Command.Args) == 0 {
+		return nil
+	}
+	return json.Unmarshal(call.Command.Args, v)
+}
+
+// CommandRecorder 命令记录器，Record 在 Runtime goroutine 中按执行顺序调用。
+type CommandRecorder interface {
+	// Record 记录一条已开始执行的命令。
+	Record(cmd Command) error
+}
+
+// NewCommandWriter 创建以 JSON Lines 格式将命令写入 w 的命令记录器。
+func NewCommandWriter(w io.Writer) CommandRecorder {
+	return &_CommandWriter{encoder: json.NewEncoder(w)}
+}
+
+type _CommandWriter struct {
+	encoder *json.Encoder
+}
+
+func (cw *_CommandWriter) Record(cmd Command) error {
+	return cw.encoder.Encode(cmd)
+}
+
+// ReadCommands 读取 NewCommandWriter 写入的全部命令。
+func ReadCommands(r io.Reader) ([]Command, error) {
+	decoder := json.NewDecoder(r)
+	var commands []Command
+	for {
+		var cmd Command
+		if err := decoder.Decode(&cmd); err != nil {
+			if errors.Is(err, io.EOF) {
+				return commands, nil
+			}
+			return nil, err
+		}
+		commands = append(commands, cmd)
+	}
+}
+
+// SubmitCommand 提交命名命令，args 编码为 JSON 后随命令一起记录。
+// Future 完成值为命令处理函数的返回结果。
+func (rt *RuntimeBehavior) SubmitCommand(name string, args any) async.Future {
+	cmd, err := rt.newCommand(name, args)
+	if err != nil {
+		return async.Rejected(err)
+	}
+	return rt.Submit(rt.commandTask(cmd))
+}
+
+// PostCommand 投递命名命令，不等待执行结果。
+func (rt *RuntimeBehavior) PostCommand(name string, args any) error {
+	cmd, err := rt.newCommand(name, args)
+	if err != nil {
+		return err
+	}
+	fun := rt.commandTask(cmd)
+	return rt.Post(func(ctx runtime.Context, _ ...any) {
+		fun(ctx)
+	})
+}
+
+// ReplayCommands 在 Manual 模式下回放命令：依次推进到每条命令记录的帧并执行该命令。
+// 回放的命令保留记录时的帧号与序号，不会再次交给 CommandRecorder 记录。
+// Future 完成值为回放结束后的当前帧号；命令所在帧已执行过时返回 ErrCommandExpired。
+func (rt *RuntimeBehavior) ReplayCommands(commands []Command) async.Future {
+	for i := range commands {
+		if _, ok := rt.options.Commands[commands[i].Name]; !ok {
+			return async.Rejected(fmt.Errorf("%w: %q", ErrCommandNotFound, commands[i].Name))
+		}
+	}
+	return rt.enqueueManual(func(ctx runtime.Context) async.Result {
+		for _, cmd := range commands {
+			for rt.frame.CurFrames() < cmd.Frame {
+				if !rt.advanceFrame(ctx) {
+					return async.NewResult(rt.frame.CurFrames(), ctx.Err())
+				}
+			}
+			if rt.frame.CurFrames() > cmd.Frame {
+				return async.NewResult(rt.frame.CurFrames(), fmt.Errorf("%w: command %q at frame %d, current frame %d", ErrCommandExpired, cmd.Name, cmd.Frame, rt.frame.CurFrames()))
+			}
+			rt.execCommand(ctx, cmd, true)
+		}
+		return async.NewResult(rt.frame.CurFrames(), nil)
+	})
+}
+
+func (rt *RuntimeBehavior) newCommand(name string, args any) (Command, error) {
+	if _, ok := rt.options.Commands[name]; !ok {
+		return Command{}, fmt.Errorf("%w: %q", ErrCommandNotFound, name)
+	}
+	cmd := Command{Name: name}
+	if args != nil {
+		data, err := json.Marshal(args)
+		if err != nil {
+			return Command{}, fmt.Errorf("%w: %w: encode command %q args: %w", ErrRuntime, ErrArgs, name, err)
+		}
+		cmd.Args = data
+	}
+	return cmd, nil
+}
+
+func (rt *RuntimeBehavior) commandTask(cmd Command) generic.FuncVar1[runtime.Context, any, async.Result] {
+	return func(ctx runtime.Context, _ ...any) async.Result {
+		return rt.execCommand(ctx, cmd, false)
+	}
+}
+
+// execCommand 执行命令；replay 为 true 时沿用命令记录的帧号与序号，且不再记录。
+func (rt *RuntimeBehavior) execCommand(ctx runtime.Context, cmd Command, replay bool) async.Result {
+	if replay {
+		rt.commandSeq = max(rt.commandSeq, cmd.Seq+1)
+	} else {
+		if rt.frame != nil {
+			cmd.Frame = rt.frame.CurFrames()
+		}
+		cmd.Seq = rt.commandSeq
+		rt.commandSeq++
+
+		if rt.options.CommandRecorder != nil {
+			if err := rt.options.CommandRecorder.Record(cmd); err != nil {
+				rt.reportError(fmt.Errorf("%w: record command %q: %w", ErrRuntime, cmd.Name, err))
+			}
+		}
+	}
+
+	ret, panicErr := rt.options.Commands[cmd.Name].Call(ctx.AutoRecover(), ctx.ReportError(), CommandCall{Context: ctx, Command: cmd})
+	if panicErr != nil {
+		return async.NewResult(nil, panicErr)
+	}
+	return ret
+}
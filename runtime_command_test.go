@@ -0,0 +1,104 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/core/utils/option"
+	"git.golaxy.org/tiny/runtime"
+)
+
+type commandTestLog struct {
+	frames  []int64
+	results []int
+	sum     int
+}
+
+// commandTestSettings 注册累加命令 add，将执行帧号与累加结果写入 log。
+func commandTestSettings(log *commandTestLog, recorder CommandRecorder) []option.Setting[RuntimeOptions] {
+	return []option.Setting[RuntimeOptions]{
+		With.Runtime.Frame(With.Frame.Mode(FrameMode_Manual)),
+		With.Runtime.CommandRecorder(recorder),
+		With.Runtime.Command("add", func(call CommandCall) async.Result {
+			var n int
+			if err := call.Decode(&n); err != nil {
+				return async.NewResult(nil, err)
+			}
+			log.sum += n
+			log.frames = append(log.frames, call.Context.Frame().CurFrames())
+			log.results = append(log.results, log.sum)
+			return async.NewResult(log.sum, nil)
+		}),
+	}
+}
+
+func TestCommandRecordReplay(t *testing.T) {
+	var recorded bytes.Buffer
+	var src commandTestLog
+	rt := startTestRuntime(t, runtime.NewContext(), commandTestSettings(&src, NewCommandWriter(&recorded))...)
+
+	for _, step := range []struct {
+		frames int64
+		arg    int
+	}{{0, 1}, {2, 2}, {1, 3}} {
+		if step.frames > 0 {
+			advanceTestFrames(t, rt, step.frames)
+		}
+		if ret := waitTestFuture(t, rt.SubmitCommand("add", step.arg)); ret.Error != nil {
+			t.Fatal(ret.Error)
+		}
+	}
+
+	commands, err := ReadCommands(bytes.NewReader(recorded.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commands) != 3 {
+		t.Fatalf("recorded commands: got %d, want 3", len(commands))
+	}
+
+	var replayed bytes.Buffer
+	var dst commandTestLog
+	replay := startTestRuntime(t, runtime.NewContext(), commandTestSettings(&dst, NewCommandWriter(&replayed))...)
+
+	ret := waitTestFuture(t, replay.ReplayCommands(commands))
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+	if cur := ret.Value.(int64); cur != 3 {
+		t.Fatalf("frame after replay: got %d, want 3", cur)
+	}
+	if !reflect.DeepEqual(dst.frames, src.frames) || !reflect.DeepEqual(dst.results, src.results) {
+		t.Fatalf("replay: got frames %v results %v, want frames %v results %v", dst.frames, dst.results, src.frames, src.results)
+	}
+	if replayed.Len() != 0 {
+		t.Fatalf("replayed commands recorded again: %s", replayed.String())
+	}
+
+	ret = waitTestFuture(t, replay.ReplayCommands(commands[:1]))
+	if !errors.Is(ret.Error, ErrCommandExpired) {
+		t.Fatalf("replay past command: got %v, want %v", ret.Error, ErrCommandExpired)
+	}
+}
@@ -116,3 +116,95 @@ type _EventLateUpdateHandler func()
 func (h _EventLateUpdateHandler) LateUpdate() {
 	h()
 }
+
+type iAutoEventFixedUpdate interface {
+	eventFixedUpdate() event.IEvent
+}
+
+func _BindEventFixedUpdate(auto iAutoEventFixedUpdate, subscriber eventFixedUpdate, priority ...int32) event.Handle {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[eventFixedUpdate](auto.eventFixedUpdate(), subscriber, priority...)
+}
+
+func _EmitEventFixedUpdate(auto iAutoEventFixedUpdate) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.eventFixedUpdate()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[eventFixedUpdate](subscriber).FixedUpdate()
+		return true
+	})
+}
+
+func _EmitEventFixedUpdateWithInterrupt(auto iAutoEventFixedUpdate, interrupt func() bool) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.eventFixedUpdate()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt() {
+				return false
+			}
+		}
+		event.Cache2Iface[eventFixedUpdate](subscriber).FixedUpdate()
+		return true
+	})
+}
+
+func _HandleEventFixedUpdate(fun func()) _EventFixedUpdateHandler {
+	return _EventFixedUpdateHandler(fun)
+}
+
+type _EventFixedUpdateHandler func()
+
+func (h _EventFixedUpdateHandler) FixedUpdate() {
+	h()
+}
+
+type iAutoEventLockstepInput interface {
+	eventLockstepInput() event.IEvent
+}
+
+func _BindEventLockstepInput(auto iAutoEventLockstepInput, subscriber eventLockstepInput, priority ...int32) event.Handle {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	return event.Bind[eventLockstepInput](auto.eventLockstepInput(), subscriber, priority...)
+}
+
+func _EmitEventLockstepInput(auto iAutoEventLockstepInput, frame int64, inputs []LockstepInput) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.eventLockstepInput()).Emit(func(subscriber event.Cache) bool {
+		event.Cache2Iface[eventLockstepInput](subscriber).LockstepInput(frame, inputs)
+		return true
+	})
+}
+
+func _EmitEventLockstepInputWithInterrupt(auto iAutoEventLockstepInput, interrupt func(frame int64, inputs []LockstepInput) bool, frame int64, inputs []LockstepInput) {
+	if auto == nil {
+		event.Panicf("%w: %w: auto is nil", event.ErrEvent, event.ErrArgs)
+	}
+	event.UnsafeEvent(auto.eventLockstepInput()).Emit(func(subscriber event.Cache) bool {
+		if interrupt != nil {
+			if interrupt(frame, inputs) {
+				return false
+			}
+		}
+		event.Cache2Iface[eventLockstepInput](subscriber).LockstepInput(frame, inputs)
+		return true
+	})
+}
+
+func _HandleEventLockstepInput(fun func(frame int64, inputs []LockstepInput)) _EventLockstepInputHandler {
+	return _EventLockstepInputHandler(fun)
+}
+
+type _EventLockstepInputHandler func(frame int64, inputs []LockstepInput)
+
+func (h _EventLockstepInputHandler) LockstepInput(frame int64, inputs []LockstepInput) {
+	h(frame, inputs)
+}
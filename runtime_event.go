@@ -32,3 +32,15 @@ type eventUpdate interface {
 type eventLateUpdate interface {
 	LateUpdate()
 }
+
+// +event-gen:export_emit=0
+// +event-tab-gen:recursion=disallow
+type eventFixedUpdate interface {
+	FixedUpdate()
+}
+
+// +event-gen:export_emit=0
+// +event-tab-gen:recursion=disallow
+type eventLockstepInput interface {
+	LockstepInput(frame int64, inputs []LockstepInput)
+}
@@ -28,15 +28,19 @@ import (
 type iRuntimeEventTab interface {
 	eventUpdate() event.IEvent
 	eventLateUpdate() event.IEvent
+	eventFixedUpdate() event.IEvent
+	eventLockstepInput() event.IEvent
 }
 
 var (
-	_runtimeEventTabID = event.DeclareEventTabIDT[runtimeEventTab]()
-	eventUpdateID      = event.DeclareEventIDT[runtimeEventTab](0)
-	eventLateUpdateID  = event.DeclareEventIDT[runtimeEventTab](1)
+	_runtimeEventTabID   = event.DeclareEventTabIDT[runtimeEventTab]()
+	eventUpdateID        = event.DeclareEventIDT[runtimeEventTab](0)
+	eventLateUpdateID    = event.DeclareEventIDT[runtimeEventTab](1)
+	eventFixedUpdateID   = event.DeclareEventIDT[runtimeEventTab](2)
+	eventLockstepInputID = event.DeclareEventIDT[runtimeEventTab](3)
 )
 
-type runtimeEventTab [2]event.Event
+type runtimeEventTab [4]event.Event
 
 func (eventTab *runtimeEventTab) SetPanicHandling(autoRecover bool, reportError chan error) {
 	for i := range eventTab {
@@ -47,6 +51,8 @@ func (eventTab *runtimeEventTab) SetPanicHandling(autoRecover bool, reportError
 func (eventTab *runtimeEventTab) SetRecursion(recursion event.EventRecursion) {
 	eventTab[0].SetRecursion(event.EventRecursion_Disallow)
 	eventTab[1].SetRecursion(event.EventRecursion_Disallow)
+	eventTab[2].SetRecursion(event.EventRecursion_Disallow)
+	eventTab[3].SetRecursion(event.EventRecursion_Disallow)
 }
 
 func (eventTab *runtimeEventTab) SetEnabled(b bool) {
@@ -75,6 +81,10 @@ func (eventTab *runtimeEventTab) Event(id uint64) event.IEvent {
 		eventTab[0].SetRecursion(event.EventRecursion_Disallow)
 	case 1:
 		eventTab[1].SetRecursion(event.EventRecursion_Disallow)
+	case 2:
+		eventTab[2].SetRecursion(event.EventRecursion_Disallow)
+	case 3:
+		eventTab[3].SetRecursion(event.EventRecursion_Disallow)
 	}
 	return &eventTab[pos]
 }
@@ -88,3 +98,13 @@ func (eventTab *runtimeEventTab) eventLateUpdate() event.IEvent {
 	eventTab.SetRecursion(event.EventRecursion_Disallow)
 	return &eventTab[1]
 }
+
+func (eventTab *runtimeEventTab) eventFixedUpdate() event.IEvent {
+	eventTab.SetRecursion(event.EventRecursion_Disallow)
+	return &eventTab[2]
+}
+
+func (eventTab *runtimeEventTab) eventLockstepInput() event.IEvent {
+	eventTab.SetRecursion(event.EventRecursion_Disallow)
+	return &eventTab[3]
+}
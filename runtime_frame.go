@@ -20,10 +20,17 @@
 package tiny
 
 import (
+	"math"
+	"sync/atomic"
 	"time"
+
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/clock"
 )
 
 type _Frame struct {
+	clock                clock.Clock
 	targetFPS            float64
 	totalFrames          int64
 	curFPS               float64
@@ -36,6 +43,20 @@ type _Frame struct {
 	lastUpdateElapseTime time.Duration
 	statFPSBeginTime     time.Time
 	statFPSFrames        int64
+	realtime             bool
+	nominalDelta         bool
+	drift                time.Duration
+	skippedFrames        atomic.Int64
+	deltaTime            time.Duration
+	simTime              time.Duration
+	timeScale            atomic.Uint64
+	paused               atomic.Bool
+	fixedDeltaTime       time.Duration
+	fixedAccumulator     time.Duration
+	maxFixedSteps        int64
+	beginFrames          int64
+	restored             bool
+	resimulating         bool
 }
 
 // TargetFPS 返回目标 FPS。
@@ -88,9 +109,122 @@ func (frame *_Frame) LastUpdateElapseTime() time.Duration {
 	return frame.lastUpdateElapseTime
 }
 
-func (frame *_Frame) init(targetFPS float64, totalFrames int64) {
-	frame.targetFPS = targetFPS
-	frame.totalFrames = totalFrames
+// Drift 返回 Realtime 模式下当前帧开始时模拟时间落后于墙钟的时长；其他模式为 0。
+func (frame *_Frame) Drift() time.Duration {
+	return frame.drift
+}
+
+// SkippedFrames 返回 Realtime 模式下因落后而跳过的帧数。
+func (frame *_Frame) SkippedFrames() int64 {
+	return frame.skippedFrames.Load()
+}
+
+// DeltaTime 返回当前帧经时间缩放后的模拟时间增量；暂停时为 0。
+func (frame *_Frame) DeltaTime() time.Duration {
+	return frame.deltaTime
+}
+
+// SimTime 返回累计的模拟时间，即各帧 DeltaTime 之和。
+func (frame *_Frame) SimTime() time.Duration {
+	return frame.simTime
+}
+
+// TimeScale 返回时间缩放系数。
+func (frame *_Frame) TimeScale() float64 {
+	return math.Float64frombits(frame.timeScale.Load())
+}
+
+// SetTimeScale 设置时间缩放系数，从下一帧开始生效；scale 必须是非负有限值。
+func (frame *_Frame) SetTimeScale(scale float64) {
+	if math.IsNaN(scale) || math.IsInf(scale, 0) || scale < 0 {
+		exception.Panicf("%w: %w: time scale must be a finite value greater than or equal to 0", runtime.ErrFrame, exception.ErrArgs)
+	}
+	frame.timeScale.Store(math.Float64bits(scale))
+}
+
+// Pause 暂停模拟时间，帧循环与邮箱继续运行，DeltaTime 为 0。
+func (frame *_Frame) Pause() {
+	frame.paused.Store(true)
+}
+
+// Resume 恢复模拟时间。
+func (frame *_Frame) Resume() {
+	frame.paused.Store(false)
+}
+
+// Paused 返回模拟时间是否已暂停。
+func (frame *_Frame) Paused() bool {
+	return frame.paused.Load()
+}
+
+// FixedDeltaTime 返回 FixedUpdate 的固定步长。
+func (frame *_Frame) FixedDeltaTime() time.Duration {
+	return frame.fixedDeltaTime
+}
+
+func (frame *_Frame) init(options FrameOptions, c clock.Clock) {
+	frame.clock = c
+	frame.realtime = options.Mode == FrameMode_Realtime
+	frame.nominalDelta = options.Mode == FrameMode_Manual || options.Mode == FrameMode_Lockstep || options.Pacing != FramePacing_Ticker
+	frame.targetFPS = options.TargetFPS
+	frame.totalFrames = options.TotalFrames
+	frame.timeScale.Store(math.Float64bits(1))
+	frame.fixedDeltaTime = options.FixedUpdateInterval
+	if frame.fixedDeltaTime <= 0 {
+		frame.fixedDeltaTime = frame.step()
+	}
+	frame.maxFixedSteps = options.MaxFixedSteps
+}
+
+func (frame *_Frame) step() time.Duration {
+	return time.Duration(float64(time.Second) / frame.targetFPS)
+}
+
+// fixedSteps 累加当前帧的 DeltaTime，返回本帧应执行的 FixedUpdate 次数。
+func (frame *_Frame) fixedSteps() int64 {
+	frame.fixedAccumulator += frame.deltaTime
+	steps := int64(frame.fixedAccumulator / frame.fixedDeltaTime)
+	if steps > frame.maxFixedSteps {
+		steps = frame.maxFixedSteps
+		frame.fixedAccumulator %= frame.fixedDeltaTime
+	} else {
+		frame.fixedAccumulator -= time.Duration(steps) * frame.fixedDeltaTime
+	}
+	return steps
+}
+
+// Resimulating 返回当前是否正在回滚后重新模拟。
+func (frame *_Frame) Resimulating() bool {
+	return frame.resimulating
+}
+
+func (frame *_Frame) setResimulating(b bool) {
+	frame.resimulating = b
+}
+
+// SnapshotFrame 返回当前帧计数、模拟时间与固定步长累积量。
+func (frame *_Frame) SnapshotFrame() runtime.FrameSnapshot {
+	return runtime.FrameSnapshot{
+		CurFrames:        frame.curFrames,
+		SimTime:          frame.simTime,
+		TimeScale:        frame.TimeScale(),
+		Paused:           frame.Paused(),
+		FixedAccumulator: frame.fixedAccumulator,
+	}
+}
+
+// RestoreFrame 将帧计数、模拟时间与固定步长累积量恢复为 snapshot；在启动前恢复时，下次启动保留恢复的帧数与模拟时间。
+func (frame *_Frame) RestoreFrame(snapshot runtime.FrameSnapshot) {
+	frame.curFrames = snapshot.CurFrames
+	frame.simTime = snapshot.SimTime
+	frame.timeScale.Store(math.Float64bits(snapshot.TimeScale))
+	frame.paused.Store(snapshot.Paused)
+	frame.fixedAccumulator = snapshot.FixedAccumulator
+	frame.restored = true
+}
+
+func (frame *_Frame) addSkippedFrames(n int64) {
+	frame.skippedFrames.Add(n)
 }
 
 func (frame *_Frame) setCurFrames(v int64) {
@@ -98,10 +232,16 @@ func (frame *_Frame) setCurFrames(v int64) {
 }
 
 func (frame *_Frame) runningBegin() {
-	now := time.Now()
+	now := frame.clock.Now()
 
 	frame.curFPS = 0
-	frame.curFrames = 0
+	// 重新启动时帧数与模拟时间从 0 开始，除非启动前已从快照恢复
+	if !frame.restored {
+		frame.curFrames = 0
+		frame.simTime = 0
+	}
+	frame.restored = false
+	frame.beginFrames = frame.curFrames
 
 	frame.statFPSBeginTime = now
 	frame.statFPSFrames = 0
@@ -114,16 +254,43 @@ func (frame *_Frame) runningBegin() {
 
 	frame.updateBeginTime = now
 	frame.lastUpdateElapseTime = 0
+
+	frame.drift = 0
+	frame.skippedFrames.Store(0)
+
+	frame.deltaTime = 0
+	frame.fixedAccumulator = 0
 }
 
 func (frame *_Frame) runningEnd() {
+	frame.restored = false
 }
 
 func (frame *_Frame) loopBegin() {
-	now := time.Now()
+	now := frame.clock.Now()
+
+	var delta time.Duration
+	if frame.nominalDelta {
+		delta = frame.step()
+	} else {
+		delta = now.Sub(frame.loopBeginTime)
+	}
+
+	if frame.Paused() {
+		frame.deltaTime = 0
+	} else {
+		frame.deltaTime = time.Duration(float64(delta) * frame.TimeScale())
+	}
+	frame.simTime += frame.deltaTime
 
 	frame.loopBeginTime = now
 
+	if frame.realtime {
+		// 启动后第 n 帧的理想开始时间为第 n+1 个步长，帧数从启动时（可能由快照恢复）的帧数起算，跳过的帧同样计入模拟时间。
+		simFrames := frame.curFrames - frame.beginFrames + frame.skippedFrames.Load() + 1
+		frame.drift = now.Sub(frame.runningBeginTime) - time.Duration(simFrames)*frame.step()
+	}
+
 	statInterval := now.Sub(frame.statFPSBeginTime).Seconds()
 	if statInterval >= 1 {
 		frame.curFPS = float64(frame.statFPSFrames) / statInterval
@@ -133,15 +300,15 @@ func (frame *_Frame) loopBegin() {
 }
 
 func (frame *_Frame) loopEnd() {
-	frame.lastLoopElapseTime = time.Now().Sub(frame.loopBeginTime)
+	frame.lastLoopElapseTime = frame.clock.Since(frame.loopBeginTime)
 	frame.runningElapseTime += frame.lastLoopElapseTime
 	frame.statFPSFrames++
 }
 
 func (frame *_Frame) updateBegin() {
-	frame.updateBeginTime = time.Now()
+	frame.updateBeginTime = frame.clock.Now()
 }
 
 func (frame *_Frame) updateEnd() {
-	frame.lastUpdateElapseTime = time.Now().Sub(frame.updateBeginTime)
+	frame.lastUpdateElapseTime = frame.clock.Since(frame.updateBeginTime)
 }
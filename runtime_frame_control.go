@@ -80,8 +80,19 @@ func (rt *RuntimeBehavior) AdvanceWhile(predicate generic.Func1[runtime.Context,
 }
 
 func (rt *RuntimeBehavior) enqueueManualAdvance(advance func(runtime.Context) int64) async.Future {
-	if rt.options.Frame.Mode != FrameMode_Manual || rt.frame == nil {
-		return async.Rejected(ErrManualFrameMode)
+	return rt.enqueueManual(func(ctx runtime.Context) async.Result {
+		return async.NewResult(advance(ctx), nil)
+	})
+}
+
+func (rt *RuntimeBehavior) enqueueManual(fun func(runtime.Context) async.Result) async.Future {
+	return rt.enqueueFrameControl(FrameMode_Manual, ErrManualFrameMode, fun)
+}
+
+// enqueueFrameControl 以帧任务投递 Manual 或 Lockstep 模式的帧控制操作；帧模式不是 mode 时返回 modeErr。
+func (rt *RuntimeBehavior) enqueueFrameControl(mode FrameMode, modeErr error, fun func(runtime.Context) async.Result) async.Future {
+	if rt.options.Frame.Mode != mode || rt.frame == nil {
+		return async.Rejected(modeErr)
 	}
 	if !rt.isRunning.Load() {
 		return async.Rejected(ErrRuntimeNotRunning)
@@ -91,7 +102,7 @@ func (rt *RuntimeBehavior) enqueueManualAdvance(advance func(runtime.Context) in
 	}
 
 	return rt.taskQueue.enqueueManualFrame(rt.ctx.ExecutorID(), func(ctx runtime.Context, _ ...any) async.Result {
-		return async.NewResult(advance(ctx), nil)
+		return fun(ctx)
 	})
 }
 
@@ -104,6 +115,7 @@ func (rt *RuntimeBehavior) advanceFrame(ctx runtime.Context) bool {
 		return false
 	}
 
+	rt.captureRollback()
 	rt.runFrame()
 
 	if total := rt.frame.TotalFrames(); total > 0 && rt.frame.CurFrames() >= total {
@@ -21,6 +21,7 @@ package tiny
 
 import (
 	"math"
+	"slices"
 	"time"
 
 	"git.golaxy.org/core/utils/exception"
@@ -35,13 +36,38 @@ const (
 	FrameMode_Disabled FrameMode = iota // 不产生 Update/LateUpdate。
 	FrameMode_Realtime                  // 按目标 FPS 自动推进。
 	FrameMode_Manual                    // 仅由 Advance 系列方法推进。
+	FrameMode_Lockstep                  // 所有锁步参与者提交本帧输入或等待超时后推进。
+)
+
+// FramePacing 定义 Realtime 模式下帧循环落后于墙钟时的处理策略。
+type FramePacing int8
+
+const (
+	FramePacing_Ticker   FramePacing = iota // 由 time.Ticker 推进，慢帧期间错过的 tick 直接丢弃并计为跳帧。
+	FramePacing_CatchUp                     // 固定步长，落后时连续补帧，单次最多补 MaxCatchUpSteps 帧，超出部分计为跳帧。
+	FramePacing_Skip                        // 固定步长，落后时只执行一帧，落后的整帧计为跳帧。
+	FramePacing_SlowDown                    // 固定步长，落后时只执行一帧且不计跳帧，时间线整体后移。
+)
+
+const (
+	FramePhase_Update     = "Update"     // 内建 Update 阶段。
+	FramePhase_LateUpdate = "LateUpdate" // 内建 LateUpdate 阶段。
 )
 
 // FrameOptions 定义运行时帧循环的选项。
 type FrameOptions struct {
-	Mode        FrameMode // 帧推进模式。
-	TargetFPS   float64   // 目标 FPS；设置时会四舍五入为整数值。
-	TotalFrames int64     // 最大运行帧数；0 表示不限制。
+	Mode                FrameMode              // 帧推进模式。
+	TargetFPS           float64                // 目标 FPS；设置时会四舍五入为整数值。
+	TotalFrames         int64                  // 最大运行帧数；0 表示不限制。
+	Pacing              FramePacing            // Realtime 模式的帧步进策略。
+	MaxCatchUpSteps     int64                  // FramePacing_CatchUp 单次最多连续补帧数。
+	FixedUpdateInterval time.Duration          // FixedUpdate 的固定步长；0 表示与帧步长一致。
+	MaxFixedSteps       int64                  // 每帧最多执行的 FixedUpdate 次数，超出的累计时间会被丢弃。
+	Phases              []string               // 每帧 FixedUpdate 之后依次执行的阶段，包含内建阶段与自定义阶段。
+	LockstepTimeout     time.Duration          // Lockstep 模式下每帧等待参与者输入的最长时间；0 表示一直等待。
+	LockstepMaxLead     int64                  // Lockstep 模式下输入帧号最多领先当前帧的帧数；0 表示不限制。
+	RollbackFrames      int64                  // Manual 模式下回滚缓冲区保存的帧数；0 表示不启用回滚。
+	RollbackCodec       runtime.ComponentCodec // 回滚保存组件状态使用的编解码器；nil 时使用 runtime.JSONComponentCodec。
 }
 
 type _FrameOption struct{}
@@ -52,6 +78,14 @@ func (_FrameOption) Default() option.Setting[FrameOptions] {
 		With.Frame.Mode(FrameMode_Realtime).Apply(options)
 		With.Frame.TargetFPS(30).Apply(options)
 		With.Frame.TotalFrames(0).Apply(options)
+		With.Frame.Pacing(FramePacing_Ticker).Apply(options)
+		With.Frame.MaxCatchUpSteps(5).Apply(options)
+		With.Frame.FixedUpdateInterval(0).Apply(options)
+		With.Frame.MaxFixedSteps(5).Apply(options)
+		With.Frame.Phases().Apply(options)
+		With.Frame.LockstepTimeout(0).Apply(options)
+		With.Frame.LockstepMaxLead(64).Apply(options)
+		With.Frame.Rollback(0, nil).Apply(options)
 	}
 }
 
@@ -59,7 +93,7 @@ func (_FrameOption) Default() option.Setting[FrameOptions] {
 func (_FrameOption) Mode(mode FrameMode) option.Setting[FrameOptions] {
 	return func(options *FrameOptions) {
 		switch mode {
-		case FrameMode_Disabled, FrameMode_Realtime, FrameMode_Manual:
+		case FrameMode_Disabled, FrameMode_Realtime, FrameMode_Manual, FrameMode_Lockstep:
 			options.Mode = mode
 		default:
 			exception.Panicf("%w: %w: invalid frame mode %d", runtime.ErrFrame, exception.ErrArgs, mode)
@@ -87,3 +121,104 @@ func (_FrameOption) TotalFrames(v int64) option.Setting[FrameOptions] {
 		options.TotalFrames = v
 	}
 }
+
+// Pacing 设置 Realtime 模式的帧步进策略。
+func (_FrameOption) Pacing(pacing FramePacing) option.Setting[FrameOptions] {
+	return func(options *FrameOptions) {
+		switch pacing {
+		case FramePacing_Ticker, FramePacing_CatchUp, FramePacing_Skip, FramePacing_SlowDown:
+			options.Pacing = pacing
+		default:
+			exception.Panicf("%w: %w: invalid frame pacing %d", runtime.ErrFrame, exception.ErrArgs, pacing)
+		}
+	}
+}
+
+// MaxCatchUpSteps 设置 FramePacing_CatchUp 单次最多连续补帧数；n 必须大于 0。
+func (_FrameOption) MaxCatchUpSteps(n int64) option.Setting[FrameOptions] {
+	return func(options *FrameOptions) {
+		if n <= 0 {
+			exception.Panicf("%w: %w: MaxCatchUpSteps must be greater than 0", runtime.ErrFrame, exception.ErrArgs)
+		}
+		options.MaxCatchUpSteps = n
+	}
+}
+
+// FixedUpdateInterval 设置 FixedUpdate 的固定步长；0 表示与帧步长一致，负值会导致 panic。
+func (_FrameOption) FixedUpdateInterval(d time.Duration) option.Setting[FrameOptions] {
+	return func(options *FrameOptions) {
+		if d < 0 {
+			exception.Panicf("%w: %w: FixedUpdateInterval must be greater than or equal to 0", runtime.ErrFrame, exception.ErrArgs)
+		}
+		options.FixedUpdateInterval = d
+	}
+}
+
+// MaxFixedSteps 设置每帧最多执行的 FixedUpdate 次数；n 必须大于 0。
+func (_FrameOption) MaxFixedSteps(n int64) option.Setting[FrameOptions] {
+	return func(options *FrameOptions) {
+		if n <= 0 {
+			exception.Panicf("%w: %w: MaxFixedSteps must be greater than 0", runtime.ErrFrame, exception.ErrArgs)
+		}
+		options.MaxFixedSteps = n
+	}
+}
+
+// Phases 按执行顺序设置帧阶段。未列出的内建阶段 FramePhase_Update 与 FramePhase_LateUpdate
+// 按此顺序追加到末尾；阶段名不能为空或重复，且 Update 必须先于 LateUpdate。
+func (_FrameOption) Phases(phases ...string) option.Setting[FrameOptions] {
+	return func(options *FrameOptions) {
+		phases = slices.Clone(phases)
+		for i, phase := range phases {
+			if phase == "" {
+				exception.Panicf("%w: %w: frame phase name is empty", runtime.ErrFrame, exception.ErrArgs)
+			}
+			if slices.Contains(phases[:i], phase) {
+				exception.Panicf("%w: %w: duplicate frame phase %q", runtime.ErrFrame, exception.ErrArgs, phase)
+			}
+		}
+		if !slices.Contains(phases, FramePhase_Update) {
+			phases = append(phases, FramePhase_Update)
+		}
+		if !slices.Contains(phases, FramePhase_LateUpdate) {
+			phases = append(phases, FramePhase_LateUpdate)
+		}
+		if slices.Index(phases, FramePhase_Update) > slices.Index(phases, FramePhase_LateUpdate) {
+			exception.Panicf("%w: %w: frame phase %q must precede %q", runtime.ErrFrame, exception.ErrArgs, FramePhase_Update, FramePhase_LateUpdate)
+		}
+		options.Phases = phases
+	}
+}
+
+// LockstepTimeout 设置 Lockstep 模式下每帧等待参与者输入的最长时间；0 表示一直等待，负值会导致 panic。
+// 超时后以已收到的输入推进该帧，缺失的输入以 LockstepInput.Missing 标记。
+func (_FrameOption) LockstepTimeout(d time.Duration) option.Setting[FrameOptions] {
+	return func(options *FrameOptions) {
+		if d < 0 {
+			exception.Panicf("%w: %w: LockstepTimeout must be greater than or equal to 0", runtime.ErrFrame, exception.ErrArgs)
+		}
+		options.LockstepTimeout = d
+	}
+}
+
+// LockstepMaxLead 设置 Lockstep 模式下输入帧号最多领先当前帧的帧数；0 表示不限制，负值会导致 panic。
+// 超出窗口的 SubmitInput 返回 ErrLockstepInputAhead，避免为远期帧无限缓存输入。
+func (_FrameOption) LockstepMaxLead(frames int64) option.Setting[FrameOptions] {
+	return func(options *FrameOptions) {
+		if frames < 0 {
+			exception.Panicf("%w: %w: LockstepMaxLead must be greater than or equal to 0", runtime.ErrFrame, exception.ErrArgs)
+		}
+		options.LockstepMaxLead = frames
+	}
+}
+
+// Rollback 设置 Manual 模式下回滚缓冲区保存的帧数与组件状态编解码器；frames 为 0 表示不启用回滚，负值会导致 panic。
+func (_FrameOption) Rollback(frames int64, codec runtime.ComponentCodec) option.Setting[FrameOptions] {
+	return func(options *FrameOptions) {
+		if frames < 0 {
+			exception.Panicf("%w: %w: RollbackFrames must be greater than or equal to 0", runtime.ErrFrame, exception.ErrArgs)
+		}
+		options.RollbackFrames = frames
+		options.RollbackCodec = codec
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/clock"
+)
+
+func newTestFrame() *_Frame {
+	frame := &_Frame{}
+	frame.init(FrameOptions{Mode: FrameMode_Manual, TargetFPS: 10}, clock.NewFake(time.Unix(1000, 0)))
+	return frame
+}
+
+func TestFrameRunningBeginResets(t *testing.T) {
+	frame := newTestFrame()
+	frame.runningBegin()
+	for range 3 {
+		frame.loopBegin()
+		frame.setCurFrames(frame.CurFrames() + 1)
+	}
+	frame.runningEnd()
+
+	frame.runningBegin()
+	if frame.CurFrames() != 0 || frame.SimTime() != 0 {
+		t.Fatalf("restart keeps frames %d, sim time %s", frame.CurFrames(), frame.SimTime())
+	}
+	frame.runningEnd()
+
+	frame.RestoreFrame(runtime.FrameSnapshot{CurFrames: 5, SimTime: time.Second, TimeScale: 1})
+	frame.runningBegin()
+	if frame.CurFrames() != 5 || frame.SimTime() != time.Second {
+		t.Fatalf("restart after restore has frames %d, sim time %s", frame.CurFrames(), frame.SimTime())
+	}
+}
+
+func TestFramePauseTimeScaleConcurrent(t *testing.T) {
+	frame := newTestFrame()
+	frame.runningBegin()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 100 {
+			frame.SetTimeScale(float64(i%3) + 1)
+			frame.Pause()
+			frame.Resume()
+		}
+	}()
+	for range 100 {
+		frame.loopBegin()
+		_ = frame.TimeScale()
+		_ = frame.Paused()
+	}
+	wg.Wait()
+
+	frame.Pause()
+	frame.loopBegin()
+	if frame.DeltaTime() != 0 {
+		t.Fatalf("paused delta time %s", frame.DeltaTime())
+	}
+}
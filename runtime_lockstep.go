@@ -0,0 +1,289 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"fmt"
+	"time"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/clock"
+)
+
+var (
+	ErrLockstepFrameMode           = fmt.Errorf("%w: lockstep frame mode required", ErrRuntime)          // 未使用 Lockstep 帧模式。
+	ErrLockstepParticipantExists   = fmt.Errorf("%w: lockstep participant already exists", ErrRuntime)   // 锁步参与者已经注册。
+	ErrLockstepParticipantNotFound = fmt.Errorf("%w: lockstep participant not found", ErrRuntime)        // 锁步参与者未注册。
+	ErrLockstepInputExpired        = fmt.Errorf("%w: lockstep input frame has passed", ErrRuntime)       // 输入对应的帧已经执行。
+	ErrLockstepInputAhead          = fmt.Errorf("%w: lockstep input frame is too far ahead", ErrRuntime) // 输入对应的帧超出领先窗口。
+)
+
+// LockstepInput 表示某个参与者为一帧提交的输入。
+type LockstepInput struct {
+	Participant string // 参与者。
+	Input       any    // 输入；Missing 为 true 时为 nil。
+	Missing     bool   // 是否因等待超时而缺失。
+}
+
+// AddLockstepParticipant 在 Lockstep 模式下注册参与者，此后每帧都需要等待该参与者的输入。
+// Future 完成值为当前帧号。
+func (rt *RuntimeBehavior) AddLockstepParticipant(participant string) async.Future {
+	return rt.enqueueFrameControl(FrameMode_Lockstep, ErrLockstepFrameMode, func(ctx runtime.Context) async.Result {
+		if err := rt.lockstep.add(participant); err != nil {
+			return async.NewResult(nil, err)
+		}
+		rt.advanceLockstep(ctx)
+		return async.NewResult(rt.frame.CurFrames(), nil)
+	})
+}
+
+// RemoveLockstepParticipant 在 Lockstep 模式下注销参与者并丢弃其尚未使用的输入，
+// 剩余参与者的输入已经齐全时随即推进。Future 完成值为当前帧号。
+func (rt *RuntimeBehavior) RemoveLockstepParticipant(participant string) async.Future {
+	return rt.enqueueFrameControl(FrameMode_Lockstep, ErrLockstepFrameMode, func(ctx runtime.Context) async.Result {
+		if err := rt.lockstep.remove(participant); err != nil {
+			return async.NewResult(nil, err)
+		}
+		rt.advanceLockstep(ctx)
+		return async.NewResult(rt.frame.CurFrames(), nil)
+	})
+}
+
+// SubmitInput 在 Lockstep 模式下提交 participant 在 frame 帧的输入；同一参与者对同一帧重复提交时以最后一次为准。
+// 当前帧的输入全部到齐时立即推进，Future 完成值为推进后的当前帧号。
+// frame 已经执行时返回 ErrLockstepInputExpired，超出当前帧加 LockstepMaxLead 时返回 ErrLockstepInputAhead，二者均可匹配 ErrArgs。
+func (rt *RuntimeBehavior) SubmitInput(participant string, frame int64, input any) async.Future {
+	if frame < 0 {
+		return async.Rejected(fmt.Errorf("%w: %w: frame must be greater than or equal to 0", ErrRuntime, ErrArgs))
+	}
+	return rt.enqueueFrameControl(FrameMode_Lockstep, ErrLockstepFrameMode, func(ctx runtime.Context) async.Result {
+		if err := rt.lockstep.submit(participant, rt.frame.CurFrames(), frame, input); err != nil {
+			return async.NewResult(nil, err)
+		}
+		rt.advanceLockstep(ctx)
+		return async.NewResult(rt.frame.CurFrames(), nil)
+	})
+}
+
+// advanceLockstep 逐帧执行输入已经齐全或等待已超时的帧，遇到仍需等待的帧时为其启动超时计时。
+func (rt *RuntimeBehavior) advanceLockstep(ctx runtime.Context) {
+	for {
+		frame := rt.frame.CurFrames()
+		if !rt.lockstep.ready(frame) {
+			rt.lockstep.wait(frame)
+			return
+		}
+		rt.lockstep.take(frame)
+		if !rt.advanceFrame(ctx) {
+			return
+		}
+	}
+}
+
+// onLockstepTimeout 在当前帧等待超时后投递推进任务，缺失的输入以 Missing 标记交付。
+// 投递失败时报告错误；该帧仍保持超时状态，下一次锁步任务会推进它。
+func (rt *RuntimeBehavior) onLockstepTimeout() {
+	frame := rt.frame.CurFrames()
+	if !rt.lockstep.expire(frame) {
+		return
+	}
+	rt.enqueueFrameControl(FrameMode_Lockstep, ErrLockstepFrameMode, func(ctx runtime.Context) async.Result {
+		rt.advanceLockstep(ctx)
+		return async.NewResult(rt.frame.CurFrames(), nil)
+	}).OnComplete(func(ret async.Result) {
+		if ret.Error != nil && rt.ctx.Err() == nil {
+			rt.reportError(fmt.Errorf("%w: advance lockstep frame %d after timeout: %w", ErrRuntime, frame, ret.Error))
+		}
+	})
+}
+
+// emitLockstepInput 在帧开始时将本帧收集到的输入交付给实体与组件。
+func (rt *RuntimeBehavior) emitLockstepInput() {
+	frame, inputs, ok := rt.lockstep.deliver()
+	if !ok {
+		return
+	}
+	_EmitEventLockstepInput(&rt.runtimeEventTab, frame, inputs)
+}
+
+// _Lockstep 保存 Lockstep 模式的参与者与按帧缓存的输入，仅在 Runtime goroutine 中访问。
+type _Lockstep struct {
+	timeout      time.Duration
+	maxLead      int64
+	participants []string
+	inputs       map[int64]map[string]any
+	timer        clock.Timer
+	timerFrame   int64
+	timerArmed   bool
+	expiredFrame int64
+	pending      []LockstepInput
+	pendingFrame int64
+	hasPending   bool
+}
+
+func (l *_Lockstep) init(c clock.Clock, timeout time.Duration, maxLead int64) {
+	l.timeout = timeout
+	l.maxLead = maxLead
+	l.inputs = map[int64]map[string]any{}
+	l.timer = c.NewTimer(timeout)
+	l.timer.Stop()
+	l.expiredFrame = -1
+}
+
+func (l *_Lockstep) add(participant string) error {
+	for _, p := range l.participants {
+		if p == participant {
+			return ErrLockstepParticipantExists
+		}
+	}
+	l.participants = append(l.participants, participant)
+	return nil
+}
+
+func (l *_Lockstep) remove(participant string) error {
+	for i, p := range l.participants {
+		if p == participant {
+			l.participants = append(l.participants[:i], l.participants[i+1:]...)
+			for _, inputs := range l.inputs {
+				delete(inputs, participant)
+			}
+			return nil
+		}
+	}
+	return ErrLockstepParticipantNotFound
+}
+
+// submit 缓存 participant 在 frame 帧的输入；frame 须位于 [cur, cur+maxLead] 内，maxLead 为 0 时不限制上界。
+func (l *_Lockstep) submit(participant string, cur, frame int64, input any) error {
+	if frame < cur {
+		return fmt.Errorf("%w: %w: frame %d is before current frame %d", ErrLockstepInputExpired, ErrArgs, frame, cur)
+	}
+	if l.maxLead > 0 && frame-cur > l.maxLead {
+		return fmt.Errorf("%w: %w: frame %d is more than %d frames ahead of current frame %d", ErrLockstepInputAhead, ErrArgs, frame, l.maxLead, cur)
+	}
+
+	found := false
+	for _, p := range l.participants {
+		if p == participant {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrLockstepParticipantNotFound
+	}
+
+	inputs, ok := l.inputs[frame]
+	if !ok {
+		inputs = map[string]any{}
+		l.inputs[frame] = inputs
+	}
+	inputs[participant] = input
+	return nil
+}
+
+// ready 返回 frame 是否可以执行：至少有一个参与者，且全部输入到齐或等待已超时。
+func (l *_Lockstep) ready(frame int64) bool {
+	if len(l.participants) <= 0 {
+		return false
+	}
+	if l.expiredFrame == frame {
+		return true
+	}
+	inputs := l.inputs[frame]
+	for _, p := range l.participants {
+		if _, ok := inputs[p]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// wait 为仍需等待的 frame 启动超时计时；未设置超时、没有参与者或已在计时时不做处理。
+func (l *_Lockstep) wait(frame int64) {
+	if l.timeout <= 0 || len(l.participants) <= 0 {
+		return
+	}
+	if l.timerArmed && l.timerFrame == frame {
+		return
+	}
+	l.timer.Reset(l.timeout)
+	l.timerFrame = frame
+	l.timerArmed = true
+}
+
+// expire 标记 frame 等待超时，返回计时是否对应该帧。
+func (l *_Lockstep) expire(frame int64) bool {
+	if !l.timerArmed {
+		return false
+	}
+	l.timerArmed = false
+	if l.timerFrame != frame {
+		return false
+	}
+	l.expiredFrame = frame
+	return true
+}
+
+// take 取出 frame 的输入，按参与者注册顺序排列，留待帧开始时交付。
+func (l *_Lockstep) take(frame int64) {
+	if l.timerArmed {
+		l.timer.Stop()
+		l.timerArmed = false
+	}
+
+	inputs := l.inputs[frame]
+	delete(l.inputs, frame)
+
+	pending := make([]LockstepInput, 0, len(l.participants))
+	for _, p := range l.participants {
+		input, ok := inputs[p]
+		pending = append(pending, LockstepInput{Participant: p, Input: input, Missing: !ok})
+	}
+
+	l.pending = pending
+	l.pendingFrame = frame
+	l.hasPending = true
+}
+
+func (l *_Lockstep) deliver() (int64, []LockstepInput, bool) {
+	if !l.hasPending {
+		return 0, nil, false
+	}
+	frame, inputs := l.pendingFrame, l.pending
+	l.pending = nil
+	l.hasPending = false
+	return frame, inputs, true
+}
+
+func (l *_Lockstep) timeoutC() <-chan time.Time {
+	if !l.timerArmed {
+		return nil
+	}
+	return l.timer.C()
+}
+
+func (l *_Lockstep) stop() {
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+	l.timerArmed = false
+}
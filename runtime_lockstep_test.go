@@ -0,0 +1,168 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/core/utils/option"
+	"git.golaxy.org/tiny/ec"
+	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/clock"
+)
+
+type lockstepRecorder struct {
+	ec.ComponentBehavior
+	frames []int64
+	inputs [][]LockstepInput
+}
+
+func (c *lockstepRecorder) LockstepInput(frame int64, inputs []LockstepInput) {
+	c.frames = append(c.frames, frame)
+	c.inputs = append(c.inputs, inputs)
+}
+
+// startLockstepTestRuntime 启动 Lockstep 模式的 Runtime，创建记录输入的实体并注册 participants。
+func startLockstepTestRuntime(t *testing.T, rtCtx runtime.Context, participants []string, settings ...option.Setting[FrameOptions]) (*RuntimeBehavior, *lockstepRecorder) {
+	t.Helper()
+
+	BuildEntityPT(rtCtx, "player").AddComponent(&lockstepRecorder{}, "recorder").Declare()
+	rt := startTestRuntime(t, rtCtx, With.Runtime.Frame(append([]option.Setting[FrameOptions]{With.Frame.Mode(FrameMode_Lockstep)}, settings...)...))
+
+	ret := waitTestFuture(t, rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		return async.NewResult(BuildEntity(ctx, "player").New())
+	}))
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+	recorder := ret.Value.(ec.Entity).GetComponent("recorder").(*lockstepRecorder)
+
+	for _, p := range participants {
+		if ret := waitTestFuture(t, rt.AddLockstepParticipant(p)); ret.Error != nil {
+			t.Fatal(ret.Error)
+		}
+	}
+	return rt, recorder
+}
+
+func submitTestInput(t *testing.T, rt *RuntimeBehavior, participant string, frame int64, input any) int64 {
+	t.Helper()
+	ret := waitTestFuture(t, rt.SubmitInput(participant, frame, input))
+	if ret.Error != nil {
+		t.Fatalf("submit input %s@%d: %v", participant, frame, ret.Error)
+	}
+	return ret.Value.(int64)
+}
+
+func checkLockstepInputs(t *testing.T, recorder *lockstepRecorder, frame int64, want []LockstepInput) {
+	t.Helper()
+	if len(recorder.frames) != 1 || recorder.frames[0] != frame {
+		t.Fatalf("delivered frames: got %v, want [%d]", recorder.frames, frame)
+	}
+	if !reflect.DeepEqual(recorder.inputs[0], want) {
+		t.Fatalf("delivered inputs: got %+v, want %+v", recorder.inputs[0], want)
+	}
+}
+
+func TestLockstepAllInputsPresent(t *testing.T) {
+	rt, recorder := startLockstepTestRuntime(t, runtime.NewContext(), []string{"a", "b"})
+
+	if cur := submitTestInput(t, rt, "a", 0, "x"); cur != 0 {
+		t.Fatalf("frame after partial input: got %d, want 0", cur)
+	}
+	if cur := submitTestInput(t, rt, "b", 0, "y"); cur != 1 {
+		t.Fatalf("frame after complete input: got %d, want 1", cur)
+	}
+
+	checkLockstepInputs(t, recorder, 0, []LockstepInput{
+		{Participant: "a", Input: "x"},
+		{Participant: "b", Input: "y"},
+	})
+}
+
+func TestLockstepTimeoutDeliversMissing(t *testing.T) {
+	const timeout = 100 * time.Millisecond
+
+	fake := clock.NewFake(time.Unix(1000, 0))
+	rt, recorder := startLockstepTestRuntime(t, runtime.NewContext(runtime.With.Clock(fake)), []string{"a", "b"},
+		With.Frame.LockstepTimeout(timeout))
+
+	if cur := submitTestInput(t, rt, "a", 0, "x"); cur != 0 {
+		t.Fatalf("frame after partial input: got %d, want 0", cur)
+	}
+
+	fake.Advance(timeout)
+	waitTestCondition(t, "frame 0 to time out", func() bool { return testCurFrames(t, rt) == 1 })
+
+	checkLockstepInputs(t, recorder, 0, []LockstepInput{
+		{Participant: "a", Input: "x"},
+		{Participant: "b", Missing: true},
+	})
+}
+
+func TestLockstepRemoveParticipantMidFrame(t *testing.T) {
+	rt, recorder := startLockstepTestRuntime(t, runtime.NewContext(), []string{"a", "b"})
+
+	submitTestInput(t, rt, "a", 0, "x")
+	submitTestInput(t, rt, "b", 1, "early")
+
+	ret := waitTestFuture(t, rt.RemoveLockstepParticipant("b"))
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+	if cur := ret.Value.(int64); cur != 1 {
+		t.Fatalf("frame after removing waiting participant: got %d, want 1", cur)
+	}
+
+	checkLockstepInputs(t, recorder, 0, []LockstepInput{
+		{Participant: "a", Input: "x"},
+	})
+}
+
+func TestLockstepInputFrameBounds(t *testing.T) {
+	rt, _ := startLockstepTestRuntime(t, runtime.NewContext(), []string{"a"}, With.Frame.LockstepMaxLead(2))
+
+	if cur := submitTestInput(t, rt, "a", 0, "x"); cur != 1 {
+		t.Fatalf("frame after input: got %d, want 1", cur)
+	}
+
+	cases := []struct {
+		frame int64
+		want  error
+	}{
+		{0, ErrLockstepInputExpired},
+		{4, ErrLockstepInputAhead},
+	}
+	for _, tc := range cases {
+		ret := waitTestFuture(t, rt.SubmitInput("a", tc.frame, "x"))
+		if !errors.Is(ret.Error, tc.want) || !errors.Is(ret.Error, ErrArgs) {
+			t.Fatalf("submit input for frame %d: got %v, want %v and ErrArgs", tc.frame, ret.Error, tc.want)
+		}
+	}
+
+	// 窗口边界内的输入被缓存，不推进当前帧
+	if cur := submitTestInput(t, rt, "a", 3, "x"); cur != 1 {
+		t.Fatalf("frame after buffered input: got %d, want 1", cur)
+	}
+}
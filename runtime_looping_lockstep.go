@@ -0,0 +1,53 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import "git.golaxy.org/tiny/runtime"
+
+func (rt *RuntimeBehavior) loopingLockstep() {
+	gcTicker := rt.clock.NewTicker(rt.options.GCInterval)
+	defer gcTicker.Stop()
+	defer rt.lockstep.stop()
+
+	taskOut := rt.taskQueue.out()
+
+loop:
+	for {
+		select {
+		case task := <-taskOut[runtime.TaskPriority_High]:
+			rt.runLaneTask(task)
+		case task := <-taskOut[runtime.TaskPriority_Normal]:
+			rt.runLaneTask(task)
+		case task := <-taskOut[runtime.TaskPriority_Low]:
+			rt.runLaneTask(task)
+		case <-rt.lockstep.timeoutC():
+			rt.onLockstepTimeout()
+		case <-rt.taskScheduler.timerC():
+			rt.runScheduledTasks()
+		case <-gcTicker.C():
+			rt.runGC()
+		case <-rt.ctx.Done():
+			break loop
+		}
+	}
+
+	rt.closeTaskQueue()
+	rt.runGC()
+}
@@ -19,10 +19,10 @@
 
 package tiny
 
-import "time"
+import "git.golaxy.org/tiny/runtime"
 
 func (rt *RuntimeBehavior) loopingManual() {
-	gcTicker := time.NewTicker(rt.options.GCInterval)
+	gcTicker := rt.clock.NewTicker(rt.options.GCInterval)
 	defer gcTicker.Stop()
 
 	taskOut := rt.taskQueue.out()
@@ -30,18 +30,21 @@ func (rt *RuntimeBehavior) loopingManual() {
 loop:
 	for {
 		select {
-		case task := <-taskOut:
-			rt.runTask(task)
-		case <-gcTicker.C:
+		case task := <-taskOut[runtime.TaskPriority_High]:
+			rt.runLaneTask(task)
+		case task := <-taskOut[runtime.TaskPriority_Normal]:
+			rt.runLaneTask(task)
+		case task := <-taskOut[runtime.TaskPriority_Low]:
+			rt.runLaneTask(task)
+		case <-rt.taskScheduler.timerC():
+			rt.runScheduledTasks()
+		case <-gcTicker.C():
 			rt.runGC()
 		case <-rt.ctx.Done():
 			break loop
 		}
 	}
 
-	rt.taskQueue.close()
-	for task := range taskOut {
-		rt.runTask(task)
-	}
+	rt.closeTaskQueue()
 	rt.runGC()
 }
@@ -19,23 +19,35 @@
 
 package tiny
 
-import (
-	"time"
-)
+import "git.golaxy.org/tiny/runtime"
 
 func (rt *RuntimeBehavior) loopingNoFrame() {
-	gcTicker := time.NewTicker(rt.options.GCInterval)
+	gcTicker := rt.clock.NewTicker(rt.options.GCInterval)
 	defer gcTicker.Stop()
 
+	var timers _NoFrameTimers
+	timers.init(rt)
+	defer timers.stop()
+
 	taskOut := rt.taskQueue.out()
 
 loop:
 	for {
 		select {
-		case task := <-taskOut:
-			rt.runTask(task)
+		case task := <-taskOut[runtime.TaskPriority_High]:
+			rt.runLaneTask(task)
+		case task := <-taskOut[runtime.TaskPriority_Normal]:
+			rt.runLaneTask(task)
+		case task := <-taskOut[runtime.TaskPriority_Low]:
+			rt.runLaneTask(task)
+
+		case <-rt.taskScheduler.timerC():
+			rt.runScheduledTasks()
+
+		case <-timers.tickC():
+			timers.run()
 
-		case <-gcTicker.C:
+		case <-gcTicker.C():
 			rt.runGC()
 
 		case <-rt.ctx.Done():
@@ -43,11 +55,7 @@ loop:
 		}
 	}
 
-	rt.taskQueue.close()
-
-	for task := range taskOut {
-		rt.runTask(task)
-	}
+	rt.closeTaskQueue()
 
 	rt.runGC()
 }
@@ -27,23 +27,45 @@ import (
 )
 
 func (rt *RuntimeBehavior) loopingRealTime() {
-	gcTicker := time.NewTicker(rt.options.GCInterval)
+	gcTicker := rt.clock.NewTicker(rt.options.GCInterval)
 	defer gcTicker.Stop()
 
 	var wg sync.WaitGroup
 
 	wg.Add(1)
-	go rt.scheduleFrameTasks(&wg, rt.frame.CurFrames(), rt.frame.TotalFrames(), rt.frame.TargetFPS())
+	if rt.options.Frame.Pacing == FramePacing_Ticker {
+		go rt.scheduleFrameTasks(&wg, rt.frame.CurFrames(), rt.frame.TotalFrames(), rt.frame.TargetFPS())
+	} else {
+		go rt.scheduleFixedFrameTasks(&wg, rt.frame.CurFrames(), rt.frame.TotalFrames(), rt.frame.TargetFPS())
+	}
 
 	taskOut := rt.taskQueue.out()
+	frameOut := rt.taskQueue.frameOut()
 
 loop:
 	for {
+		laneOut := taskOut
+		if rt.taskBudget.spent() {
+			// 邮箱预算耗尽，暂停各通道出队，等待帧任务执行后恢复
+			laneOut = [taskLaneCount]<-chan _Task{}
+		}
+
 		select {
-		case task := <-taskOut:
+		case task := <-frameOut:
 			rt.runTask(task)
+			rt.taskBudget.reset()
+
+		case task := <-laneOut[runtime.TaskPriority_High]:
+			rt.runLaneTask(task)
+		case task := <-laneOut[runtime.TaskPriority_Normal]:
+			rt.runLaneTask(task)
+		case task := <-laneOut[runtime.TaskPriority_Low]:
+			rt.runLaneTask(task)
 
-		case <-gcTicker.C:
+		case <-rt.taskScheduler.timerC():
+			rt.runScheduledTasks()
+
+		case <-gcTicker.C():
 			rt.runGC()
 
 		case <-rt.ctx.Done():
@@ -52,23 +74,25 @@ loop:
 	}
 
 	wg.Wait()
-	rt.taskQueue.close()
-
-	for task := range taskOut {
-		rt.runTask(task)
-	}
+	rt.closeTaskQueue()
 
 	rt.runGC()
 }
 
+// scheduleFrameTasks 按 Ticker 节拍投递帧任务；帧执行超时时 Ticker 丢弃错过的节拍，按节拍时间换算出丢弃数量计为跳帧。
 func (rt *RuntimeBehavior) scheduleFrameTasks(wg *sync.WaitGroup, curFrames, totalFrames int64, targetFPS float64) {
 	defer wg.Done()
 
-	updateTicker := time.NewTicker(time.Duration(float64(time.Second) / targetFPS))
+	step := time.Duration(float64(time.Second) / targetFPS)
+
+	begin := rt.clock.Now()
+	updateTicker := rt.clock.NewTicker(step)
 	defer updateTicker.Stop()
 
 	done := make(chan struct{}, 1)
 
+	var ticks int64
+
 	for {
 		if totalFrames > 0 && curFrames >= totalFrames {
 			rt.Terminate()
@@ -76,7 +100,12 @@ func (rt *RuntimeBehavior) scheduleFrameTasks(wg *sync.WaitGroup, curFrames, tot
 		}
 
 		select {
-		case <-updateTicker.C:
+		case tick := <-updateTicker.C():
+			ticks++
+			if n := int64(tick.Sub(begin) / step); n > ticks {
+				rt.frame.addSkippedFrames(n - ticks)
+				ticks = n
+			}
 			if !rt.taskQueue.enqueueFrame(rt.ctx, rt.frameLoop, done) {
 				return
 			}
@@ -87,16 +116,96 @@ func (rt *RuntimeBehavior) scheduleFrameTasks(wg *sync.WaitGroup, curFrames, tot
 	}
 }
 
+// scheduleFixedFrameTasks 以固定步长累加墙钟时间，按 Pacing 策略决定落后时补帧、跳帧或整体放慢。
+func (rt *RuntimeBehavior) scheduleFixedFrameTasks(wg *sync.WaitGroup, curFrames, totalFrames int64, targetFPS float64) {
+	defer wg.Done()
+
+	step := time.Duration(float64(time.Second) / targetFPS)
+
+	stepTimer := rt.clock.NewTimer(step)
+	defer stepTimer.Stop()
+
+	done := make(chan struct{}, 1)
+
+	last := rt.clock.Now()
+	var accumulator time.Duration
+
+	for {
+		select {
+		case <-stepTimer.C():
+		case <-rt.ctx.Done():
+			return
+		}
+
+		now := rt.clock.Now()
+		accumulator += now.Sub(last)
+		last = now
+
+		steps := int64(accumulator / step)
+		if steps <= 0 {
+			stepTimer.Reset(step - accumulator)
+			continue
+		}
+
+		var run, skipped int64
+
+		switch rt.options.Frame.Pacing {
+		case FramePacing_CatchUp:
+			run = min(steps, rt.options.Frame.MaxCatchUpSteps)
+			skipped = steps - run
+			accumulator -= time.Duration(steps) * step
+		case FramePacing_Skip:
+			run = 1
+			skipped = steps - 1
+			accumulator -= time.Duration(steps) * step
+		default:
+			run = 1
+			accumulator = 0
+		}
+
+		if skipped > 0 {
+			rt.frame.addSkippedFrames(skipped)
+		}
+
+		for ; run > 0; run-- {
+			if totalFrames > 0 && curFrames >= totalFrames {
+				rt.Terminate()
+				return
+			}
+			if !rt.taskQueue.enqueueFrame(rt.ctx, rt.frameLoop, done) {
+				return
+			}
+			curFrames++
+		}
+
+		if totalFrames > 0 && curFrames >= totalFrames {
+			rt.Terminate()
+			return
+		}
+
+		stepTimer.Reset(step - accumulator)
+	}
+}
+
 func (rt *RuntimeBehavior) frameLoop(runtime.Context, ...any) {
 	rt.runFrame()
 }
 
 func (rt *RuntimeBehavior) frameLoopBegin() {
 	rt.emitEventRunningEvent(runtime.RunningEvent_FrameLoopBegin)
+	if !rt.frame.Resimulating() {
+		rt.runScheduledFrameTasks()
+		rt.runFrameTimers()
+	}
 	rt.emitEventRunningEvent(runtime.RunningEvent_FrameUpdateBegin)
 
-	_EmitEventUpdate(&rt.runtimeEventTab)
-	_EmitEventLateUpdate(&rt.runtimeEventTab)
+	rt.emitLockstepInput()
+
+	for steps := rt.frame.fixedSteps(); steps > 0; steps-- {
+		_EmitEventFixedUpdate(&rt.runtimeEventTab)
+	}
+
+	rt.emitFramePhases()
 
 	rt.emitEventRunningEvent(runtime.RunningEvent_FrameUpdateEnd)
 }
@@ -104,4 +213,6 @@ func (rt *RuntimeBehavior) frameLoopBegin() {
 func (rt *RuntimeBehavior) frameLoopEnd() {
 	rt.emitEventRunningEvent(runtime.RunningEvent_FrameLoopEnd)
 	rt.frame.setCurFrames(rt.frame.CurFrames() + 1)
+	// 帧间调用 PostAfterFrames 等以已完成帧数为基准，不能沿用帧开始时记录的值
+	rt.taskScheduler.frames.Store(rt.frame.CurFrames())
 }
@@ -0,0 +1,108 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"testing"
+	"time"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/clock"
+)
+
+// waitTestCondition 轮询 cond 直至其返回 true，超时则测试失败。
+func waitTestCondition(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func testCurFrames(t *testing.T, rt *RuntimeBehavior) int64 {
+	t.Helper()
+	ret := waitTestFuture(t, rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		return async.NewResult(ctx.Frame().CurFrames(), nil)
+	}))
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+	return ret.Value.(int64)
+}
+
+func TestRealtimePacingSkippedFrames(t *testing.T) {
+	const step = 100 * time.Millisecond
+
+	cases := []struct {
+		name    string
+		pacing  FramePacing
+		settled int64 // 超时帧结束后无需推进时钟即可执行到的帧数。
+		frames  int64
+		skipped int64
+	}{
+		{"Ticker", FramePacing_Ticker, 3, 4, 2},
+		{"CatchUp", FramePacing_CatchUp, 2, 6, 0},
+		{"Skip", FramePacing_Skip, 2, 3, 3},
+		{"SlowDown", FramePacing_SlowDown, 2, 3, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := clock.NewFake(time.Unix(1000, 0))
+			rt := startTestRuntime(t, runtime.NewContext(runtime.With.Clock(fake)),
+				With.Runtime.Frame(
+					With.Frame.Mode(FrameMode_Realtime),
+					With.Frame.TargetFPS(float64(time.Second/step)),
+					With.Frame.Pacing(tc.pacing),
+					With.Frame.MaxCatchUpSteps(10),
+				),
+			)
+
+			// 第 2 帧执行期间墙钟前进 3 个步长，模拟超时帧
+			if err := rt.PostAtFrame(1, func(runtime.Context, ...any) { fake.Advance(3 * step) }); err != nil {
+				t.Fatal(err)
+			}
+
+			// GC Ticker 与帧节拍计时器均就绪后再推进时钟
+			advance := func() {
+				waitTestCondition(t, "frame timer", func() bool { return fake.Waiters() == 2 })
+				fake.Advance(step)
+			}
+
+			advance()
+			waitTestCondition(t, "frame 1", func() bool { return testCurFrames(t, rt) >= 1 })
+			advance()
+			waitTestCondition(t, "overrun frame", func() bool { return testCurFrames(t, rt) >= tc.settled })
+			advance()
+			waitTestCondition(t, "frames after overrun", func() bool { return testCurFrames(t, rt) >= tc.frames })
+
+			if got := testCurFrames(t, rt); got != tc.frames {
+				t.Errorf("frames: got %d, want %d", got, tc.frames)
+			}
+			if got := rt.frame.SkippedFrames(); got != tc.skipped {
+				t.Errorf("skipped frames: got %d, want %d", got, tc.skipped)
+			}
+		})
+	}
+}
@@ -34,13 +34,18 @@ type (
 
 // RuntimeOptions 定义创建运行时及其工作循环时使用的选项。
 type RuntimeOptions struct {
-	InstanceFace                    iface.Face[Runtime] // 自定义运行时实例及其接口缓存。
-	AutoRun                         bool                // 是否在 RunningEvent_Birth 后自动启动运行时。
-	ContinueOnActivatingEntityPanic bool                // 激活实体发生 panic 后是否继续；为 false 时销毁该实体。
-	Frame                           FrameOptions        // 帧循环配置。
-	TaskQueue                       TaskQueueOptions    // 任务队列配置。
-	GCInterval                      time.Duration       // 两次运行时 GC 之间的最短间隔。
-	CustomGC                        CustomGC            // 内置清理完成后执行的自定义 GC。
+	InstanceFace                    iface.Face[Runtime]       // 自定义运行时实例及其接口缓存。
+	AutoRun                         bool                      // 是否在 RunningEvent_Birth 后自动启动运行时。
+	ContinueOnActivatingEntityPanic bool                      // 激活实体发生 panic 后是否继续；为 false 时销毁该实体。
+	Frame                           FrameOptions              // 帧循环配置。
+	TaskQueue                       TaskQueueOptions          // 任务队列配置。
+	GCInterval                      time.Duration             // 两次运行时 GC 之间的最短间隔。
+	CustomGC                        CustomGC                  // 内置清理完成后执行的自定义 GC。
+	WatchdogThreshold               time.Duration             // 单个任务或帧的执行时长阈值，超过时报告；0 表示关闭看门狗。
+	WatchdogCB                      WatchdogCB                // 看门狗报告回调；为 nil 时以非阻塞方式发送到 ReportError。
+	Commands                        map[string]CommandHandler // 已注册的命名命令。
+	CommandRecorder                 CommandRecorder           // 命令记录器；nil 表示不记录。
+	Registry                        *Registry                 // 运行时启动后注册、终止时注销的注册表；nil 表示不注册。
 }
 
 type _RuntimeOption struct{}
@@ -55,6 +60,11 @@ func (_RuntimeOption) Default() option.Setting[RuntimeOptions] {
 		With.Runtime.TaskQueue(With.TaskQueue.Default()).Apply(options)
 		With.Runtime.GCInterval(10 * time.Second).Apply(options)
 		With.Runtime.CustomGC(nil).Apply(options)
+		With.Runtime.WatchdogThreshold(0).Apply(options)
+		With.Runtime.WatchdogCB(nil).Apply(options)
+		With.Runtime.Commands(nil).Apply(options)
+		With.Runtime.CommandRecorder(nil).Apply(options)
+		With.Runtime.Registry(nil).Apply(options)
 	}
 }
 
@@ -110,3 +120,59 @@ func (_RuntimeOption) CustomGC(fn CustomGC) option.Setting[RuntimeOptions] {
 		options.CustomGC = fn
 	}
 }
+
+// WatchdogThreshold 设置看门狗阈值；0 表示关闭，负值会导致 panic。
+func (_RuntimeOption) WatchdogThreshold(dur time.Duration) option.Setting[RuntimeOptions] {
+	return func(options *RuntimeOptions) {
+		if dur < 0 {
+			exception.Panicf("%w: %w: WatchdogThreshold must be greater than or equal to 0", ErrRuntime, ErrArgs)
+		}
+		options.WatchdogThreshold = dur
+	}
+}
+
+// WatchdogCB 设置看门狗报告回调，回调在看门狗 goroutine 中执行，不应访问 Runtime 局部状态。
+func (_RuntimeOption) WatchdogCB(fn WatchdogCB) option.Setting[RuntimeOptions] {
+	return func(options *RuntimeOptions) {
+		options.WatchdogCB = fn
+	}
+}
+
+// Commands 设置全部命名命令，替换之前注册的命令。
+func (_RuntimeOption) Commands(commands map[string]CommandHandler) option.Setting[RuntimeOptions] {
+	return func(options *RuntimeOptions) {
+		options.Commands = nil
+		for name, handler := range commands {
+			With.Runtime.Command(name, handler).Apply(options)
+		}
+	}
+}
+
+// Command 注册命名命令，name 重复时覆盖之前的处理函数。
+func (_RuntimeOption) Command(name string, handler CommandHandler) option.Setting[RuntimeOptions] {
+	return func(options *RuntimeOptions) {
+		if handler == nil {
+			exception.Panicf("%w: %w: command %q handler is nil", ErrRuntime, ErrArgs, name)
+		}
+		commands := make(map[string]CommandHandler, len(options.Commands)+1)
+		for k, v := range options.Commands {
+			commands[k] = v
+		}
+		commands[name] = handler
+		options.Commands = commands
+	}
+}
+
+// CommandRecorder 设置命令记录器，nil 表示不记录。
+func (_RuntimeOption) CommandRecorder(recorder CommandRecorder) option.Setting[RuntimeOptions] {
+	return func(options *RuntimeOptions) {
+		options.CommandRecorder = recorder
+	}
+}
+
+// Registry 设置运行时注册表，运行时在 RunningEvent_Started 时注册、RunningEvent_Terminated 时注销；nil 表示不注册。
+func (_RuntimeOption) Registry(registry *Registry) option.Setting[RuntimeOptions] {
+	return func(options *RuntimeOptions) {
+		options.Registry = registry
+	}
+}
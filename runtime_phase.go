@@ -0,0 +1,123 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"slices"
+
+	"git.golaxy.org/core/event"
+	"git.golaxy.org/tiny/ec"
+	"git.golaxy.org/tiny/ec/pt"
+)
+
+type eventPhaseUpdate interface {
+	PhaseUpdate(phase string)
+}
+
+type _EventPhaseUpdateHandler func(phase string)
+
+func (h _EventPhaseUpdateHandler) PhaseUpdate(phase string) {
+	h(phase)
+}
+
+// _FramePhase 是帧循环中的一个阶段，自定义阶段持有独立的订阅事件。
+type _FramePhase struct {
+	name  string
+	event event.Event
+}
+
+func (rt *RuntimeBehavior) initFramePhases() {
+	rt.framePhases = make([]_FramePhase, len(rt.options.Frame.Phases))
+	for i, name := range rt.options.Frame.Phases {
+		phase := &rt.framePhases[i]
+		phase.name = name
+		phase.event.SetPanicHandling(rt.ctx.AutoRecover(), rt.ctx.ReportError())
+		phase.event.SetRecursion(event.EventRecursion_Disallow)
+	}
+}
+
+// emitFramePhases 按配置顺序执行内建与自定义帧阶段。
+func (rt *RuntimeBehavior) emitFramePhases() {
+	for i := range rt.framePhases {
+		phase := &rt.framePhases[i]
+
+		switch phase.name {
+		case FramePhase_Update:
+			_EmitEventUpdate(&rt.runtimeEventTab)
+		case FramePhase_LateUpdate:
+			_EmitEventLateUpdate(&rt.runtimeEventTab)
+		default:
+			event.UnsafeEvent(&phase.event).Emit(func(subscriber event.Cache) bool {
+				event.Cache2Iface[eventPhaseUpdate](subscriber).PhaseUpdate(phase.name)
+				return true
+			})
+		}
+	}
+}
+
+// bindFramePhases 将 subscriber 绑定到 phases 中已注册的自定义阶段，未注册的阶段会被忽略。
+func (rt *RuntimeBehavior) bindFramePhases(subscriber eventPhaseUpdate, phases []string) []event.Handle {
+	var handles []event.Handle
+
+	for i := range rt.framePhases {
+		phase := &rt.framePhases[i]
+
+		switch phase.name {
+		case FramePhase_Update, FramePhase_LateUpdate:
+			continue
+		}
+
+		if !slices.Contains(phases, phase.name) {
+			continue
+		}
+
+		handles = append(handles, event.Bind[eventPhaseUpdate](&phase.event, subscriber))
+	}
+
+	return handles
+}
+
+func entityFramePhases(entity ec.Entity) []string {
+	if cb, ok := entity.(LifecycleEntityFramePhases); ok {
+		return cb.FramePhases()
+	}
+	return nil
+}
+
+func componentFramePhases(comp ec.Component) []string {
+	var phases []string
+
+	if cb, ok := comp.(LifecycleComponentFramePhases); ok {
+		phases = append(phases, cb.FramePhases()...)
+	}
+
+	switch v := comp.Builtin().Meta.ToGoMap()[pt.MetaFramePhases].(type) {
+	case []string:
+		phases = append(phases, v...)
+	case []any:
+		for _, phase := range v {
+			if phase, ok := phase.(string); ok {
+				phases = append(phases, phase)
+			}
+		}
+	}
+
+	return phases
+}
@@ -0,0 +1,175 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"fmt"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/tiny/ec"
+	"git.golaxy.org/tiny/runtime"
+)
+
+var (
+	ErrRollbackDisabled    = fmt.Errorf("%w: rollback is disabled", ErrRuntime)            // 未启用回滚。
+	ErrRollbackUnavailable = fmt.Errorf("%w: rollback frame is not available", ErrRuntime) // 目标帧的状态不在回滚缓冲区中。
+)
+
+// Rollback 在 Manual 模式下将状态回退到 frame 帧开始前，在 Runtime goroutine 中执行 correction 修正状态，
+// 再重新模拟到回退前的当前帧。重新模拟期间 Frame.Resimulating 返回 true。
+// 重新模拟只重放 FixedUpdate、Update、LateUpdate 与自定义阶段等帧回调：原先各帧中触发的定时器、按帧调度的任务、
+// 邮箱任务与已交付的 Lockstep 输入既不会再次执行，也不会被记录重放，它们对组件状态的影响需由 correction 自行补上。
+// Future 完成值为重新模拟后的当前帧号；frame 的状态已不在回滚缓冲区时返回 ErrRollbackUnavailable。
+func (rt *RuntimeBehavior) Rollback(frame int64, correction generic.Action1[runtime.Context]) async.Future {
+	if !rt.rollback.enabled() {
+		return async.Rejected(ErrRollbackDisabled)
+	}
+	return rt.enqueueManual(func(ctx runtime.Context) async.Result {
+		target := rt.frame.CurFrames()
+		if frame < 0 || frame > target {
+			return async.NewResult(nil, fmt.Errorf("%w: %w: frame must be between 0 and %d", ErrRuntime, ErrArgs, target))
+		}
+
+		state, ok := rt.rollback.get(frame)
+		if !ok {
+			return async.NewResult(nil, ErrRollbackUnavailable)
+		}
+		if err := rt.rollback.restore(state); err != nil {
+			return async.NewResult(nil, err)
+		}
+		rt.frame.RestoreFrame(state.frame)
+
+		correction.Call(ctx.AutoRecover(), ctx.ReportError(), ctx)
+
+		rt.frame.setResimulating(true)
+		defer rt.frame.setResimulating(false)
+
+		for rt.frame.CurFrames() < target {
+			if !rt.advanceFrame(ctx) {
+				break
+			}
+		}
+
+		return async.NewResult(rt.frame.CurFrames(), nil)
+	})
+}
+
+// captureRollback 在帧开始前保存当前状态；编码失败时该帧不可回滚，错误以非阻塞方式发送到 ReportError。
+func (rt *RuntimeBehavior) captureRollback() {
+	if !rt.rollback.enabled() {
+		return
+	}
+	if err := rt.rollback.capture(rt.ctx, rt.frame); err != nil {
+		rt.reportError(err)
+	}
+}
+
+type _RollbackComponent struct {
+	comp ec.Component
+	data []byte
+}
+
+type _RollbackState struct {
+	valid      bool
+	frame      runtime.FrameSnapshot
+	components []_RollbackComponent
+}
+
+// _Rollback 以环形缓冲区保存最近若干帧开始前的组件状态与帧状态，仅在 Runtime goroutine 中访问。
+// 回退只恢复仍存在的组件的数据，不撤销期间创建或销毁的实体与组件。
+type _Rollback struct {
+	codec  runtime.ComponentCodec
+	states []_RollbackState
+}
+
+func (rb *_Rollback) init(options FrameOptions) {
+	if options.RollbackFrames <= 0 {
+		return
+	}
+	rb.codec = options.RollbackCodec
+	if rb.codec == nil {
+		rb.codec = runtime.JSONComponentCodec{}
+	}
+	rb.states = make([]_RollbackState, options.RollbackFrames)
+}
+
+func (rb *_Rollback) enabled() bool {
+	return len(rb.states) > 0
+}
+
+func (rb *_Rollback) slot(frame int64) *_RollbackState {
+	return &rb.states[frame%int64(len(rb.states))]
+}
+
+func (rb *_Rollback) capture(ctx runtime.Context, frame *_Frame) error {
+	state := rb.slot(frame.CurFrames())
+	state.valid = false
+	state.frame = frame.SnapshotFrame()
+	state.components = state.components[:0]
+
+	var err error
+	ctx.EntityManager().RangeEntities(func(entity ec.Entity) bool {
+		if entity.State() > ec.EntityState_Alive {
+			return true
+		}
+		entity.RangeComponents(func(comp ec.Component) bool {
+			if comp.State() > ec.ComponentState_Alive {
+				return true
+			}
+			var data []byte
+			data, err = rb.codec.Encode(comp)
+			if err != nil {
+				err = fmt.Errorf("%w: rollback: encode component %q: %w", ErrRuntime, comp.Name(), err)
+				return false
+			}
+			state.components = append(state.components, _RollbackComponent{comp: comp, data: data})
+			return true
+		})
+		return err == nil
+	})
+	if err != nil {
+		state.components = state.components[:0]
+		return err
+	}
+
+	state.valid = true
+	return nil
+}
+
+func (rb *_Rollback) get(frame int64) (*_RollbackState, bool) {
+	state := rb.slot(frame)
+	if !state.valid || state.frame.CurFrames != frame {
+		return nil, false
+	}
+	return state, true
+}
+
+func (rb *_Rollback) restore(state *_RollbackState) error {
+	for _, rc := range state.components {
+		if rc.comp.State() > ec.ComponentState_Alive || rc.data == nil {
+			continue
+		}
+		if err := rb.codec.Decode(rc.comp, rc.data); err != nil {
+			return fmt.Errorf("%w: rollback: decode component %q: %w", ErrRuntime, rc.comp.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"maps"
+	"testing"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/tiny/ec"
+	"git.golaxy.org/tiny/runtime"
+)
+
+type rollbackState struct {
+	ec.ComponentBehavior
+	N     int
+	Items map[string]int
+	Ptr   *int
+}
+
+func TestRollbackRestoresMapField(t *testing.T) {
+	rtCtx := runtime.NewContext()
+	BuildEntityPT(rtCtx, "unit").AddComponent(&rollbackState{}, "state").Declare()
+	rt := startTestRuntime(t, rtCtx, With.Runtime.Frame(
+		With.Frame.Mode(FrameMode_Manual),
+		With.Frame.Rollback(4, nil),
+	))
+
+	ret := waitTestFuture(t, rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		entity, err := BuildEntity(ctx, "unit").New()
+		if err != nil {
+			return async.NewResult(nil, err)
+		}
+		state := entity.GetComponent("state").(*rollbackState)
+		state.N = 1
+		state.Items = map[string]int{"a": 1}
+		return async.NewResult(state, nil)
+	}))
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+	state := ret.Value.(*rollbackState)
+
+	// 第 0 帧开始前捕获状态
+	advanceTestFrames(t, rt, 1)
+
+	waitTestFuture(t, rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		state.N = 2
+		state.Items["a"] = 5
+		state.Items["b"] = 2
+		p := 3
+		state.Ptr = &p
+		return async.NewResult(nil, nil)
+	}))
+
+	ret = waitTestFuture(t, rt.Rollback(0, func(runtime.Context) {}))
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+	if cur := ret.Value.(int64); cur != 1 {
+		t.Fatalf("frame after rollback: got %d, want 1", cur)
+	}
+
+	waitTestFuture(t, rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		if state.N != 1 {
+			t.Errorf("N after rollback: got %d, want 1", state.N)
+		}
+		if want := map[string]int{"a": 1}; !maps.Equal(state.Items, want) {
+			t.Errorf("Items after rollback: got %v, want %v", state.Items, want)
+		}
+		if state.Ptr != nil {
+			t.Errorf("Ptr after rollback: got %d, want nil", *state.Ptr)
+		}
+		return async.NewResult(nil, nil)
+	}))
+}
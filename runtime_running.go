@@ -20,9 +20,6 @@
 package tiny
 
 import (
-	"context"
-	"time"
-
 	"git.golaxy.org/core/event"
 	"git.golaxy.org/core/extension"
 	"git.golaxy.org/core/utils/async"
@@ -74,6 +71,11 @@ func (rt *RuntimeBehavior) Terminated() async.Signal {
 func (rt *RuntimeBehavior) running() {
 	ctx := rt.ctx
 
+	rt.taskQueue.setOwner(currentGoroutineID())
+
+	rt.watchdog.start()
+	defer rt.watchdog.stop()
+
 	rt.emitEventRunningEvent(runtime.RunningEvent_Starting)
 
 	handles := rt.loopStart()
@@ -86,8 +88,7 @@ func (rt *RuntimeBehavior) running() {
 
 	rt.loopStop(handles)
 
-	ctx.AsyncScope().Close()
-	_ = ctx.AsyncScope().Done().Wait(context.Background())
+	rt.closeAsyncScopes()
 
 	corectx.UnsafeContext(ctx).CloseWaitGroup()
 	ctx.WaitGroup().Wait()
@@ -101,6 +102,8 @@ func (rt *RuntimeBehavior) running() {
 	}
 
 	corectx.UnsafeContext(ctx).ReturnTerminated()
+
+	rt.finishGracefulTermination()
 }
 
 func (rt *RuntimeBehavior) emitEventRunningEvent(runningEvent runtime.RunningEvent, args ...any) {
@@ -128,6 +131,16 @@ func (rt *RuntimeBehavior) onAfterContextRunningEvent(ctx runtime.Context, runni
 		if rt.options.AutoRun {
 			rt.getInstance().Run()
 		}
+	case runtime.RunningEvent_Started:
+		if rt.options.Registry != nil {
+			if err := rt.options.Registry.Register(rt.getInstance()); err != nil {
+				rt.reportError(err)
+			}
+		}
+	case runtime.RunningEvent_Terminated:
+		if rt.options.Registry != nil {
+			rt.options.Registry.Unregister(rt.getInstance())
+		}
 	}
 }
 
@@ -225,6 +238,7 @@ func (rt *RuntimeBehavior) loopStart() []event.Handle {
 		runtime.BindEventEntityManagerEntityRemoveComponent(ctx.EntityManager(), rt.handleEventEntityManagerEntityRemoveComponent),
 		runtime.BindEventEntityManagerEntityComponentEnableChanged(ctx.EntityManager(), rt.handleEventEntityManagerEntityComponentEnableChanged),
 		runtime.BindEventEntityManagerEntityFirstTouchComponent(ctx.EntityManager(), rt.handleEventEntityManagerEntityFirstTouchComponent),
+		runtime.BindEventEntityManagerEntityUpdateIntervalChanged(ctx.EntityManager(), rt.handleEventEntityManagerEntityUpdateIntervalChanged),
 	}
 }
 
@@ -244,21 +258,32 @@ func (rt *RuntimeBehavior) mainLoop() {
 		rt.loopingRealTime()
 	case FrameMode_Manual:
 		rt.loopingManual()
+	case FrameMode_Lockstep:
+		rt.loopingLockstep()
 	}
 }
 
 func (rt *RuntimeBehavior) runTask(task _Task) {
-	rt.taskQueue.start(task.typ)
-	rt.lastProgressTime.Store(time.Now().UnixNano())
+	if !task.begin() {
+		rt.taskQueue.skip(&task)
+		rt.lastProgressTime.Store(rt.clock.Now().UnixNano())
+		return
+	}
+
+	rt.taskQueue.takeKeyed(&task)
+	rt.taskQueue.start(&task)
+	rt.watchdog.begin(task.typ)
+	defer rt.watchdog.end()
+	rt.lastProgressTime.Store(rt.clock.Now().UnixNano())
 
 	var panicked bool
 	defer func() {
 		if panicValue := recover(); panicValue != nil {
 			panicked = true
-			rt.finishTask(task.typ, panicked)
+			rt.finishTask(&task, panicked)
 			panic(panicValue)
 		}
-		rt.finishTask(task.typ, panicked)
+		rt.finishTask(&task, panicked)
 	}()
 	switch task.typ {
 	case TaskType_Submit, TaskType_Post:
@@ -270,9 +295,54 @@ func (rt *RuntimeBehavior) runTask(task _Task) {
 	}
 }
 
-func (rt *RuntimeBehavior) finishTask(taskType TaskType, panicked bool) {
-	rt.taskQueue.complete(taskType, panicked)
-	rt.lastProgressTime.Store(time.Now().UnixNano())
+func (rt *RuntimeBehavior) finishTask(task *_Task, panicked bool) {
+	rt.taskQueue.complete(task, panicked)
+	rt.lastProgressTime.Store(rt.clock.Now().UnixNano())
+}
+
+// runLaneTask 执行主循环从任一通道收到的任务。
+// 启用优先级通道时，先将任务放回所属通道队首，再按优先级执行一批积压任务，批量上限为进入时的积压数量，
+// 批次结束时仍留在队首的任务随即执行，避免其在主循环 select 中不可见。
+// 启用帧间邮箱预算时，预算耗尽即提前结束批次。
+func (rt *RuntimeBehavior) runLaneTask(task _Task) {
+	if !rt.taskQueue.prioritized {
+		rt.runBudgetedTask(task)
+		return
+	}
+
+	rt.taskQueue.unshift(task)
+
+	for n := rt.taskQueue.backlog(); n > 0 && !rt.taskBudget.spent(); n-- {
+		task, ok := rt.taskQueue.dequeue()
+		if !ok {
+			return
+		}
+		rt.runBudgetedTask(task)
+	}
+
+	if task, ok := rt.taskQueue.shift(); ok {
+		rt.runBudgetedTask(task)
+	}
+}
+
+// runBudgetedTask 执行任务，并在启用帧间邮箱预算时计入预算。
+func (rt *RuntimeBehavior) runBudgetedTask(task _Task) {
+	if !rt.taskBudget.enabled() {
+		rt.runTask(task)
+		return
+	}
+
+	begin := rt.clock.Now()
+	defer func() {
+		rt.taskBudget.consume(rt.clock.Since(begin))
+	}()
+	rt.runTask(task)
+}
+
+func (rt *RuntimeBehavior) closeTaskQueue() {
+	rt.taskQueue.close()
+	rt.drainTaskQueue()
+	rt.closeTaskScheduler()
 }
 
 func (rt *RuntimeBehavior) runGC() {
@@ -0,0 +1,113 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/tiny/runtime"
+)
+
+var (
+	ErrFrameLoopDisabled = fmt.Errorf("%w: frame loop is disabled", ErrRuntime) // 未启用帧循环。
+)
+
+// PostAfter 在 dur 后将无返回值函数投递到 Runtime 执行。
+// Manual 与 Lockstep 模式下按 TargetFPS 将 dur 换算为帧数，随帧推进确定性地执行。
+func (rt *RuntimeBehavior) PostAfter(dur time.Duration, fun generic.ActionVar1[runtime.Context, any], args ...any) error {
+	task := _Task{typ: TaskType_Post, lane: runtime.TaskPriority_Normal, action: fun, args: args}
+	if rt.options.Frame.Mode == FrameMode_Manual || rt.options.Frame.Mode == FrameMode_Lockstep {
+		return rt.schedulePostAtFrame(rt.taskScheduler.frames.Load()+durationFrames(dur, rt.options.Frame.TargetFPS), task)
+	}
+	return rt.schedulePostAt(rt.clock.Now().Add(dur), task)
+}
+
+// PostAtFrame 在已完成帧数达到 frame 后的首个帧循环开始时执行无返回值函数，先于该帧的 Update。
+// frame 已经过去时在下一个帧循环开始时执行。
+func (rt *RuntimeBehavior) PostAtFrame(frame int64, fun generic.ActionVar1[runtime.Context, any], args ...any) error {
+	if rt.frame == nil {
+		return ErrFrameLoopDisabled
+	}
+	return rt.schedulePostAtFrame(frame, _Task{typ: TaskType_Post, lane: runtime.TaskPriority_Normal, action: fun, args: args})
+}
+
+// PostAfterFrames 在再完成 frames 帧后的首个帧循环开始时执行无返回值函数。
+func (rt *RuntimeBehavior) PostAfterFrames(frames int64, fun generic.ActionVar1[runtime.Context, any], args ...any) error {
+	if rt.frame == nil {
+		return ErrFrameLoopDisabled
+	}
+	if frames < 0 {
+		return fmt.Errorf("%w: %w: frames must be greater than or equal to 0", ErrRuntime, ErrArgs)
+	}
+	return rt.schedulePostAtFrame(rt.taskScheduler.frames.Load()+frames, _Task{typ: TaskType_Post, lane: runtime.TaskPriority_Normal, action: fun, args: args})
+}
+
+func (rt *RuntimeBehavior) schedulePostAt(at time.Time, task _Task) error {
+	if err := rt.taskQueue.hold(&task); err != nil {
+		return err
+	}
+	if !rt.taskScheduler.scheduleAt(at, task) {
+		rt.taskQueue.skip(&task)
+		return ErrTaskQueueClosed
+	}
+	return nil
+}
+
+func (rt *RuntimeBehavior) schedulePostAtFrame(frame int64, task _Task) error {
+	if err := rt.taskQueue.hold(&task); err != nil {
+		return err
+	}
+	if !rt.taskScheduler.scheduleAtFrame(frame, task) {
+		rt.taskQueue.skip(&task)
+		return ErrTaskQueueClosed
+	}
+	return nil
+}
+
+// runScheduledTasks 执行已到期的延迟任务。
+func (rt *RuntimeBehavior) runScheduledTasks() {
+	for _, task := range rt.taskScheduler.dueTasks(rt.clock.Now()) {
+		rt.runTask(task)
+	}
+}
+
+// runScheduledFrameTasks 在帧循环开始时执行已到期的帧任务。
+func (rt *RuntimeBehavior) runScheduledFrameTasks() {
+	for _, task := range rt.taskScheduler.dueFrameTasks(rt.frame.CurFrames()) {
+		rt.runTask(task)
+	}
+}
+
+// closeTaskScheduler 关闭调度器，未到期的任务记为取消。
+func (rt *RuntimeBehavior) closeTaskScheduler() {
+	for _, task := range rt.taskScheduler.close() {
+		rt.taskQueue.skip(&task)
+	}
+}
+
+func durationFrames(dur time.Duration, targetFPS float64) int64 {
+	if dur <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(dur.Seconds() * targetFPS))
+}
@@ -0,0 +1,71 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"testing"
+
+	"git.golaxy.org/tiny/runtime"
+)
+
+func TestPostAfterFramesCountsCompletedFrames(t *testing.T) {
+	rt := startTestRuntime(t, runtime.NewContext(), With.Runtime.Frame(With.Frame.Mode(FrameMode_Manual)))
+
+	fired := make(chan int64, 2)
+	record := func(ctx runtime.Context, _ ...any) {
+		fired <- ctx.Frame().CurFrames()
+	}
+
+	advanceTestFrames(t, rt, 2)
+
+	// 帧间调用：基准为已完成的 2 帧，须再完成 1 帧后执行
+	if err := rt.PostAfterFrames(1, record); err != nil {
+		t.Fatal(err)
+	}
+	advanceTestFrames(t, rt, 1)
+	select {
+	case frame := <-fired:
+		t.Fatalf("between-frame PostAfterFrames(1) fired at frame %d before another frame completed", frame)
+	default:
+	}
+	advanceTestFrames(t, rt, 1)
+	if frame := <-fired; frame != 3 {
+		t.Fatalf("between-frame PostAfterFrames(1) fired at frame %d, want 3", frame)
+	}
+
+	// 帧内调用：在第 4 帧开始时登记，须待该帧完成后执行
+	if err := rt.PostAtFrame(4, func(ctx runtime.Context, _ ...any) {
+		if err := rt.PostAfterFrames(1, record); err != nil {
+			t.Error(err)
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	advanceTestFrames(t, rt, 1)
+	select {
+	case frame := <-fired:
+		t.Fatalf("in-frame PostAfterFrames(1) fired at frame %d in the same frame", frame)
+	default:
+	}
+	advanceTestFrames(t, rt, 1)
+	if frame := <-fired; frame != 5 {
+		t.Fatalf("in-frame PostAfterFrames(1) fired at frame %d, want 5", frame)
+	}
+}
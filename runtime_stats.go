@@ -19,7 +19,12 @@
 
 package tiny
 
-import "git.golaxy.org/core/utils/async"
+import (
+	"time"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/tiny/runtime"
+)
 
 // TaskQueueStats 描述一种调度语义的 Runtime 邮箱统计。
 type TaskQueueStats struct {
@@ -31,6 +36,8 @@ type TaskQueueStats struct {
 	Panicked       int64 // Completed 中恢复过 panic 的数量。
 	RejectedClosed int64 // 因队列关闭而拒绝的数量。
 	RejectedFull   int64 // 因有界队列容量不足而拒绝的数量。
+	Coalesced      int64 // 因相同 key 的任务仍在排队而合并的投递数量。
+	Held           int64 // 当前由调度器暂存、尚未到期的延迟任务数，不计入 Queued。
 }
 
 // RuntimeTaskStats 按 Submit、Post 和 Frame 三种调度语义分类。
@@ -38,6 +45,22 @@ type RuntimeTaskStats struct {
 	Submit TaskQueueStats
 	Post   TaskQueueStats
 	Frame  TaskQueueStats
+	Lanes  RuntimeLaneStats
+}
+
+// RuntimeLaneStats 按优先级通道汇总任务统计；未启用优先级通道时全部计入 Normal。
+type RuntimeLaneStats struct {
+	High   TaskQueueStats
+	Normal TaskQueueStats
+	Low    TaskQueueStats
+}
+
+// RuntimeBackpressureStats 汇总 SubmitWait/PostWait 等待有界队列容量的情况。
+type RuntimeBackpressureStats struct {
+	Waits       int64         // 发生过阻塞等待的入队次数。
+	Abandoned   int64         // Waits 中因 ctx 结束或队列关闭而放弃入队的次数。
+	WaitTime    time.Duration // 累计等待时长。
+	MaxWaitTime time.Duration // 单次最长等待时长。
 }
 
 // RuntimeHealthStats 描述 Runtime 当前执行健康状态。
@@ -53,6 +76,7 @@ type RuntimeStats struct {
 	WaitGroupCount  int64
 	WaitGroupClosed bool
 	Tasks           RuntimeTaskStats
+	Backpressure    RuntimeBackpressureStats
 	Scope           async.ScopeStats
 	Health          RuntimeHealthStats
 }
@@ -71,6 +95,8 @@ func snapshotTaskStats(stats *_TaskQueueStats) TaskQueueStats {
 		Panicked:       stats.panicked.Load(),
 		RejectedClosed: stats.rejectedClosed.Load(),
 		RejectedFull:   stats.rejectedFull.Load(),
+		Coalesced:      stats.coalesced.Load(),
+		Held:           stats.held.Load(),
 	}
 	return ret
 }
@@ -84,6 +110,17 @@ func (rt *RuntimeBehavior) Stats() RuntimeStats {
 			Submit: snapshotTaskStats(&rt.taskQueue.stats[TaskType_Submit]),
 			Post:   snapshotTaskStats(&rt.taskQueue.stats[TaskType_Post]),
 			Frame:  snapshotTaskStats(&rt.taskQueue.stats[TaskType_Frame]),
+			Lanes: RuntimeLaneStats{
+				High:   snapshotTaskStats(&rt.taskQueue.lanes[runtime.TaskPriority_High].stats),
+				Normal: snapshotTaskStats(&rt.taskQueue.lanes[runtime.TaskPriority_Normal].stats),
+				Low:    snapshotTaskStats(&rt.taskQueue.lanes[runtime.TaskPriority_Low].stats),
+			},
+		},
+		Backpressure: RuntimeBackpressureStats{
+			Waits:       rt.taskQueue.waitStats.waits.Load(),
+			Abandoned:   rt.taskQueue.waitStats.abandoned.Load(),
+			WaitTime:    time.Duration(rt.taskQueue.waitStats.waitTime.Load()),
+			MaxWaitTime: time.Duration(rt.taskQueue.waitStats.maxWaitTime.Load()),
 		},
 		Scope: rt.ctx.AsyncScope().Stats(),
 		Health: RuntimeHealthStats{
@@ -20,6 +20,9 @@
 package tiny
 
 import (
+	"context"
+	"sync/atomic"
+
 	"git.golaxy.org/core/utils/async"
 	"git.golaxy.org/core/utils/generic"
 	"git.golaxy.org/tiny/runtime"
@@ -37,6 +40,7 @@ const (
 
 type _Task struct {
 	typ          TaskType
+	lane         runtime.TaskPriority
 	fun          generic.FuncVar1[runtime.Context, any, async.Result]
 	action       generic.ActionVar1[runtime.Context, any]
 	delegate     generic.DelegateVar1[runtime.Context, any, async.Result]
@@ -44,6 +48,29 @@ type _Task struct {
 	args         []any
 	promise      async.Promise
 	done         chan struct{}
+	ctx          context.Context
+	guard        *_TaskGuard
+	stop         func() bool
+	keyed        *_TaskKeyed
+	held         bool
+}
+
+// _TaskKeyed 保存按 key 合并的任务在排队期间被替换的最新回调与参数。
+type _TaskKeyed struct {
+	key    any
+	typ    TaskType
+	lane   runtime.TaskPriority
+	action generic.ActionVar1[runtime.Context, any]
+	args   []any
+}
+
+// begin 在任务出队时确认其仍可执行；任务已被撤回时返回 false。
+func (task _Task) begin() bool {
+	ok := task.guard.start()
+	if task.stop != nil {
+		task.stop()
+	}
+	return ok
 }
 
 func (task _Task) run(ctx runtime.Context) (panicked bool) {
@@ -79,3 +106,44 @@ func (task _Task) run(ctx runtime.Context) (panicked bool) {
 	}
 	return panicked
 }
+
+const (
+	taskGuard_Pending int32 = iota
+	taskGuard_Started
+	taskGuard_Canceled
+)
+
+// _TaskGuard 在任务开始执行与撤回之间仲裁，保证二者只有一方生效。
+type _TaskGuard struct {
+	state   atomic.Int32
+	promise async.Promise
+}
+
+func (g *_TaskGuard) start() bool {
+	return g == nil || g.state.CompareAndSwap(taskGuard_Pending, taskGuard_Started)
+}
+
+// cancel 撤回尚未开始的任务，并以 err 完成其 Future。
+func (g *_TaskGuard) cancel(err error) bool {
+	if g == nil || !g.state.CompareAndSwap(taskGuard_Pending, taskGuard_Canceled) {
+		return false
+	}
+	if !g.promise.IsNil() {
+		g.promise.Resolve(async.NewResult(nil, err))
+	}
+	return true
+}
+
+// _TaskHandle 实现 runtime.TaskHandle。
+type _TaskHandle struct {
+	guard  *_TaskGuard
+	future async.Future
+}
+
+func (h *_TaskHandle) Cancel() bool {
+	return h.guard.cancel(ErrTaskCanceled)
+}
+
+func (h *_TaskHandle) Future() async.Future {
+	return h.future
+}
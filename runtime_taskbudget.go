@@ -0,0 +1,63 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import "time"
+
+// _TaskBudget 记录 Realtime 模式下两帧之间邮箱任务的执行预算。
+type _TaskBudget struct {
+	tasks    int
+	duration time.Duration
+	used     int
+	elapsed  time.Duration
+}
+
+func (b *_TaskBudget) init(options TaskQueueOptions) {
+	b.tasks = options.FrameBudgetTasks
+	b.duration = options.FrameBudgetTime
+	b.reset()
+}
+
+func (b *_TaskBudget) enabled() bool {
+	return b.tasks > 0 || b.duration > 0
+}
+
+// reset 在帧任务执行后重置预算。
+func (b *_TaskBudget) reset() {
+	b.used = 0
+	b.elapsed = 0
+}
+
+// consume 计入一个已执行的邮箱任务及其耗时。
+func (b *_TaskBudget) consume(elapsed time.Duration) {
+	b.used++
+	b.elapsed += elapsed
+}
+
+// spent 返回本帧预算是否已经耗尽。
+func (b *_TaskBudget) spent() bool {
+	if b.tasks > 0 && b.used >= b.tasks {
+		return true
+	}
+	if b.duration > 0 && b.elapsed >= b.duration {
+		return true
+	}
+	return false
+}
@@ -22,18 +22,25 @@ package tiny
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"git.golaxy.org/core/utils/async"
 	"git.golaxy.org/core/utils/generic"
 	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/clock"
 )
 
 var (
 	ErrTaskQueueClosed = fmt.Errorf("%w: task queue is closed", ErrRuntime) // 任务队列已关闭。
 	ErrTaskQueueFull   = fmt.Errorf("%w: task queue is full", ErrRuntime)   // 任务队列已满。
+	ErrTaskCanceled    = fmt.Errorf("%w: task is canceled", ErrRuntime)     // 任务在开始执行前被撤回。
 )
 
+const taskLaneCount = int(runtime.TaskPriority_Low) + 1
+
 type _TaskQueueStats struct {
 	accepted       atomic.Int64
 	queued         atomic.Int64
@@ -43,58 +50,244 @@ type _TaskQueueStats struct {
 	panicked       atomic.Int64
 	rejectedClosed atomic.Int64
 	rejectedFull   atomic.Int64
+	coalesced      atomic.Int64
+	held           atomic.Int64
 }
 
-type _TaskQueue struct {
-	barrier       generic.Barrier
+// waiting 返回任务开始执行前所计入的计数：调度器暂存的任务计入 held，其余计入 queued。
+func (stats *_TaskQueueStats) waiting(task *_Task) *atomic.Int64 {
+	if task.held {
+		return &stats.held
+	}
+	return &stats.queued
+}
+
+type _TaskLane struct {
 	boundedChan   chan _Task
 	unboundedChan *generic.UnboundedChannel[_Task]
-	stats         [taskTypeCount]_TaskQueueStats
+	stats         _TaskQueueStats
+	head          _Task
+	hasHead       bool
+	streak        int
 }
 
-func (q *_TaskQueue) init(unbounded bool, capacity int) {
+func (lane *_TaskLane) init(unbounded bool, capacity int) {
 	if unbounded {
-		q.unboundedChan = generic.NewUnboundedChannel[_Task]()
+		lane.unboundedChan = generic.NewUnboundedChannel[_Task]()
 	} else {
-		q.boundedChan = make(chan _Task, capacity)
+		lane.boundedChan = make(chan _Task, capacity)
 	}
 }
 
-func (q *_TaskQueue) enqueueSubmit(
-	executorID async.ExecutorID,
-	fun generic.FuncVar1[runtime.Context, any, async.Result],
-	action generic.ActionVar1[runtime.Context, any],
-	delegate generic.DelegateVar1[runtime.Context, any, async.Result],
-	delegateVoid generic.DelegateVoidVar1[runtime.Context, any],
-	args []any,
-) async.Future {
-	promise, future := async.NewPromise(executorID)
-	task := _Task{
-		typ:          TaskType_Submit,
-		fun:          fun,
-		action:       action,
-		delegate:     delegate,
-		delegateVoid: delegateVoid,
-		args:         args,
-		promise:      promise,
+func (lane *_TaskLane) out() <-chan _Task {
+	if lane.boundedChan != nil {
+		return lane.boundedChan
 	}
-	if err := q.tryEnqueue(task); err != nil {
+	if lane.unboundedChan != nil {
+		return lane.unboundedChan.Out()
+	}
+	return nil
+}
+
+func (lane *_TaskLane) pop() (_Task, bool) {
+	if lane.hasHead {
+		task := lane.head
+		lane.head = _Task{}
+		lane.hasHead = false
+		return task, true
+	}
+	select {
+	case task, ok := <-lane.out():
+		return task, ok
+	default:
+		return _Task{}, false
+	}
+}
+
+func (lane *_TaskLane) close() {
+	if lane.boundedChan != nil {
+		close(lane.boundedChan)
+	}
+	if lane.unboundedChan != nil {
+		lane.unboundedChan.Close()
+	}
+}
+
+type _TaskQueueWaitStats struct {
+	waits       atomic.Int64
+	abandoned   atomic.Int64
+	waitTime    atomic.Int64
+	maxWaitTime atomic.Int64
+}
+
+func (stats *_TaskQueueWaitStats) record(waitTime time.Duration, abandoned bool) {
+	stats.waits.Add(1)
+	if abandoned {
+		stats.abandoned.Add(1)
+	}
+	stats.waitTime.Add(int64(waitTime))
+	for {
+		maxWaitTime := stats.maxWaitTime.Load()
+		if int64(waitTime) <= maxWaitTime || stats.maxWaitTime.CompareAndSwap(maxWaitTime, int64(waitTime)) {
+			return
+		}
+	}
+}
+
+type _TaskQueue struct {
+	barrier         generic.Barrier
+	closing         chan struct{}
+	lanes           [taskLaneCount]_TaskLane
+	prioritized     bool
+	starvationLimit int
+	stats           [taskTypeCount]_TaskQueueStats
+	waitStats       _TaskQueueWaitStats
+	keyedMutex      sync.Mutex
+	keyed           map[any]*_TaskKeyed
+	frameChan       chan _Task
+	clock           clock.Clock
+	ownerGID        atomic.Pointer[string]
+}
+
+func (q *_TaskQueue) init(options TaskQueueOptions, c clock.Clock) {
+	q.clock = c
+	q.closing = make(chan struct{})
+	q.keyed = map[any]*_TaskKeyed{}
+	q.prioritized = options.PriorityLanes
+	q.starvationLimit = options.StarvationLimit
+
+	if !q.prioritized {
+		q.lanes[runtime.TaskPriority_Normal].init(options.Unbounded, options.Capacity)
+		return
+	}
+	for i := range q.lanes {
+		q.lanes[i].init(options.Unbounded, options.Capacity)
+	}
+}
+
+// checkTaskPriority 校验任务优先级，公开入口据此拒绝非法参数而不是 panic。
+func checkTaskPriority(priority runtime.TaskPriority) error {
+	if priority < runtime.TaskPriority_High || priority > runtime.TaskPriority_Low {
+		return fmt.Errorf("%w: %w: invalid task priority %d", ErrRuntime, ErrArgs, priority)
+	}
+	return nil
+}
+
+// lane 返回任务实际进入的通道；priority 须为合法优先级。
+func (q *_TaskQueue) lane(priority runtime.TaskPriority) runtime.TaskPriority {
+	if !q.prioritized {
+		return runtime.TaskPriority_Normal
+	}
+	return priority
+}
+
+// assignLane 校验任务优先级并设置其实际进入的通道。
+func (q *_TaskQueue) assignLane(task *_Task) error {
+	if err := checkTaskPriority(task.lane); err != nil {
+		return err
+	}
+	task.lane = q.lane(task.lane)
+	return nil
+}
+
+func (q *_TaskQueue) enqueueSubmit(executorID async.ExecutorID, task _Task, waitCtx context.Context) async.Future {
+	promise, future := async.NewPromise(executorID)
+	task.typ = TaskType_Submit
+	if err := q.assignLane(&task); err != nil {
 		promise.Resolve(async.NewResult(nil, err))
+		return future
+	}
+	task.promise = promise
+	if task.ctx != nil && task.guard == nil {
+		task.guard = &_TaskGuard{}
+	}
+	if task.guard != nil {
+		task.guard.promise = promise
+	}
+	if task.ctx != nil {
+		task.stop = context.AfterFunc(task.ctx, func() {
+			task.guard.cancel(task.ctx.Err())
+		})
+	}
+	if err := q.enqueue(task, waitCtx); err != nil {
+		if task.stop != nil {
+			task.stop()
+		}
+		// 有 guard 时由其仲裁 Future 的完成，ctx 已先行撤回时不再重复完成
+		if task.guard != nil {
+			task.guard.cancel(err)
+		} else {
+			promise.Resolve(async.NewResult(nil, err))
+		}
 	}
 	return future
 }
 
-func (q *_TaskQueue) enqueuePost(
-	action generic.ActionVar1[runtime.Context, any],
-	delegateVoid generic.DelegateVoidVar1[runtime.Context, any],
-	args []any,
-) error {
-	return q.tryEnqueue(_Task{
-		typ:          TaskType_Post,
-		action:       action,
-		delegateVoid: delegateVoid,
-		args:         args,
-	})
+func (q *_TaskQueue) enqueuePost(task _Task, waitCtx context.Context) error {
+	task.typ = TaskType_Post
+	if err := q.assignLane(&task); err != nil {
+		return err
+	}
+	if err := q.enqueue(task, waitCtx); err != nil {
+		task.guard.cancel(err)
+		return err
+	}
+	return nil
+}
+
+// enqueuePostKeyed 投递按 key 合并的任务；相同 key 的任务仍在排队时只替换其回调与参数。
+func (q *_TaskQueue) enqueuePostKeyed(key any, task _Task) error {
+	if key == nil {
+		return fmt.Errorf("%w: %w: key is nil", ErrRuntime, ErrArgs)
+	}
+	if !reflect.TypeOf(key).Comparable() {
+		return fmt.Errorf("%w: %w: key type %T is not comparable", ErrRuntime, ErrArgs, key)
+	}
+
+	task.typ = TaskType_Post
+	if err := q.assignLane(&task); err != nil {
+		return err
+	}
+
+	q.keyedMutex.Lock()
+	defer q.keyedMutex.Unlock()
+
+	if keyed, ok := q.keyed[key]; ok {
+		keyed.action = task.action
+		keyed.args = task.args
+		q.stats[keyed.typ].coalesced.Add(1)
+		q.lanes[keyed.lane].stats.coalesced.Add(1)
+		return nil
+	}
+
+	keyed := &_TaskKeyed{key: key, typ: task.typ, lane: task.lane, action: task.action, args: task.args}
+	task.action = nil
+	task.args = nil
+	task.keyed = keyed
+
+	if err := q.tryEnqueue(task); err != nil {
+		return err
+	}
+	q.keyed[key] = keyed
+
+	return nil
+}
+
+// takeKeyed 在合并任务开始执行前取出最新的回调与参数，之后同 key 的投递将重新入队。
+func (q *_TaskQueue) takeKeyed(task *_Task) {
+	if task.keyed == nil {
+		return
+	}
+
+	q.keyedMutex.Lock()
+	defer q.keyedMutex.Unlock()
+
+	if q.keyed[task.keyed.key] == task.keyed {
+		delete(q.keyed, task.keyed.key)
+	}
+	task.action = task.keyed.action
+	task.args = task.keyed.args
+	task.keyed = nil
 }
 
 func (q *_TaskQueue) enqueueManualFrame(
@@ -104,6 +297,7 @@ func (q *_TaskQueue) enqueueManualFrame(
 	promise, future := async.NewPromise(executorID)
 	if err := q.tryEnqueue(_Task{
 		typ:     TaskType_Frame,
+		lane:    q.lane(runtime.TaskPriority_Normal),
 		fun:     fun,
 		promise: promise,
 	}); err != nil {
@@ -112,108 +306,339 @@ func (q *_TaskQueue) enqueueManualFrame(
 	return future
 }
 
+// enableFrameChan 为帧任务启用独立通道，使主循环在邮箱预算耗尽后仍能及时取到帧任务。
+func (q *_TaskQueue) enableFrameChan() {
+	q.frameChan = make(chan _Task, 1)
+}
+
+// frameOut 返回帧任务的独立通道；未启用时返回 nil。
+func (q *_TaskQueue) frameOut() <-chan _Task {
+	return q.frameChan
+}
+
 func (q *_TaskQueue) enqueueFrame(ctx context.Context, action generic.ActionVar1[runtime.Context, any], done chan struct{}) bool {
-	task := _Task{typ: TaskType_Frame, action: action, done: done}
+	task := _Task{typ: TaskType_Frame, lane: q.lane(runtime.TaskPriority_High), action: action, done: done}
+	lane := &q.lanes[task.lane]
 	stats := &q.stats[TaskType_Frame]
 
-	if q.boundedChan != nil {
+	if q.frameChan != nil {
 		stats.queued.Add(1)
+		lane.stats.queued.Add(1)
 		select {
-		case q.boundedChan <- task:
+		case q.frameChan <- task:
 			stats.accepted.Add(1)
+			lane.stats.accepted.Add(1)
 			select {
 			case <-done:
 				return true
 			case <-ctx.Done():
 				stats.canceled.Add(1)
+				lane.stats.canceled.Add(1)
 				return false
 			}
 		case <-ctx.Done():
 			stats.queued.Add(-1)
 			stats.rejectedClosed.Add(1)
+			lane.stats.queued.Add(-1)
+			lane.stats.rejectedClosed.Add(1)
 			return false
 		}
 	}
 
-	if q.unboundedChan != nil {
+	if lane.boundedChan != nil {
 		stats.queued.Add(1)
-		q.unboundedChan.In() <- task
+		lane.stats.queued.Add(1)
+		select {
+		case lane.boundedChan <- task:
+			stats.accepted.Add(1)
+			lane.stats.accepted.Add(1)
+			select {
+			case <-done:
+				return true
+			case <-ctx.Done():
+				stats.canceled.Add(1)
+				lane.stats.canceled.Add(1)
+				return false
+			}
+		case <-ctx.Done():
+			stats.queued.Add(-1)
+			stats.rejectedClosed.Add(1)
+			lane.stats.queued.Add(-1)
+			lane.stats.rejectedClosed.Add(1)
+			return false
+		}
+	}
+
+	if lane.unboundedChan != nil {
+		stats.queued.Add(1)
+		lane.stats.queued.Add(1)
+		lane.unboundedChan.In() <- task
 		stats.accepted.Add(1)
+		lane.stats.accepted.Add(1)
 		select {
 		case <-done:
 			return true
 		case <-ctx.Done():
 			stats.canceled.Add(1)
+			lane.stats.canceled.Add(1)
 			return false
 		}
 	}
 
 	stats.rejectedClosed.Add(1)
+	lane.stats.rejectedClosed.Add(1)
 	return false
 }
 
 func (q *_TaskQueue) tryEnqueue(task _Task) error {
+	return q.enqueue(task, nil)
+}
+
+// enqueue 将任务放入所属通道。waitCtx 为 nil 时有界队列已满立即返回 ErrTaskQueueFull，
+// 否则阻塞等待容量，直至 waitCtx 结束或队列关闭。
+func (q *_TaskQueue) enqueue(task _Task, waitCtx context.Context) error {
+	lane := &q.lanes[task.lane]
 	stats := &q.stats[task.typ]
 	if !q.barrier.Join(1) {
 		stats.rejectedClosed.Add(1)
+		lane.stats.rejectedClosed.Add(1)
 		return ErrTaskQueueClosed
 	}
 	defer q.barrier.Done()
 
-	if q.boundedChan != nil {
+	if lane.boundedChan != nil {
 		stats.queued.Add(1)
+		lane.stats.queued.Add(1)
 		select {
-		case q.boundedChan <- task:
+		case lane.boundedChan <- task:
 			stats.accepted.Add(1)
+			lane.stats.accepted.Add(1)
 			return nil
 		default:
+		}
+
+		if waitCtx == nil {
 			stats.queued.Add(-1)
 			stats.rejectedFull.Add(1)
+			lane.stats.queued.Add(-1)
+			lane.stats.rejectedFull.Add(1)
 			return ErrTaskQueueFull
 		}
+
+		// 只有 Runtime goroutine 能腾出容量，在其中阻塞等待必然死锁
+		if q.calledByOwner() {
+			stats.queued.Add(-1)
+			stats.rejectedFull.Add(1)
+			lane.stats.queued.Add(-1)
+			lane.stats.rejectedFull.Add(1)
+			return fmt.Errorf("%w: %w", ErrTaskQueueFull, runtime.ErrRuntimeSelfWait)
+		}
+
+		waitBegin := q.clock.Now()
+		select {
+		case lane.boundedChan <- task:
+			q.waitStats.record(q.clock.Since(waitBegin), false)
+			stats.accepted.Add(1)
+			lane.stats.accepted.Add(1)
+			return nil
+		case <-waitCtx.Done():
+			q.waitStats.record(q.clock.Since(waitBegin), true)
+			stats.queued.Add(-1)
+			lane.stats.queued.Add(-1)
+			return waitCtx.Err()
+		case <-q.closing:
+			q.waitStats.record(q.clock.Since(waitBegin), true)
+			stats.queued.Add(-1)
+			stats.rejectedClosed.Add(1)
+			lane.stats.queued.Add(-1)
+			lane.stats.rejectedClosed.Add(1)
+			return ErrTaskQueueClosed
+		}
 	}
 
-	if q.unboundedChan != nil {
+	if lane.unboundedChan != nil {
 		stats.queued.Add(1)
-		q.unboundedChan.In() <- task
+		lane.stats.queued.Add(1)
+		lane.unboundedChan.In() <- task
 		stats.accepted.Add(1)
+		lane.stats.accepted.Add(1)
 		return nil
 	}
 
 	stats.rejectedClosed.Add(1)
+	lane.stats.rejectedClosed.Add(1)
 	return ErrTaskQueueClosed
 }
 
-func (q *_TaskQueue) out() <-chan _Task {
-	if q.boundedChan != nil {
-		return q.boundedChan
+// setOwner 记录消费队列的 Runtime goroutine，供阻塞入队识别自等待。
+func (q *_TaskQueue) setOwner(gid string) {
+	q.ownerGID.Store(&gid)
+}
+
+// calledByOwner 返回调用方是否为消费队列的 Runtime goroutine；仅在有界队列已满、即将阻塞时检查。
+func (q *_TaskQueue) calledByOwner() bool {
+	gid := q.ownerGID.Load()
+	return gid != nil && *gid != "" && *gid == currentGoroutineID()
+}
+
+// hold 为不经通道、由调度器暂存的任务登记统计；暂存的任务计入 held 而非 queued，不参与通道积压判断。
+func (q *_TaskQueue) hold(task *_Task) error {
+	if err := q.assignLane(task); err != nil {
+		return err
 	}
-	if q.unboundedChan != nil {
-		return q.unboundedChan.Out()
+	task.held = true
+	lane := &q.lanes[task.lane]
+	stats := &q.stats[task.typ]
+	if !q.barrier.Join(1) {
+		stats.rejectedClosed.Add(1)
+		lane.stats.rejectedClosed.Add(1)
+		return ErrTaskQueueClosed
 	}
+	defer q.barrier.Done()
+
+	stats.held.Add(1)
+	stats.accepted.Add(1)
+	lane.stats.held.Add(1)
+	lane.stats.accepted.Add(1)
 	return nil
 }
 
-func (q *_TaskQueue) start(typ TaskType) {
-	q.stats[typ].queued.Add(-1)
-	q.stats[typ].running.Add(1)
+func (q *_TaskQueue) out() [taskLaneCount]<-chan _Task {
+	var out [taskLaneCount]<-chan _Task
+	for i := range q.lanes {
+		out[i] = q.lanes[i].out()
+	}
+	return out
+}
+
+// unshift 将主循环从 lane 通道收到的任务放回该通道队首，交由 dequeue 按优先级重新选择。
+func (q *_TaskQueue) unshift(task _Task) {
+	lane := &q.lanes[task.lane]
+	lane.head = task
+	lane.hasHead = true
+}
+
+// dequeue 按 High、Normal、Low 的顺序非阻塞取出任务。
+// 某个通道连续出队达到 starvationLimit 且更低优先级通道有积压时，让出一次出队机会。
+func (q *_TaskQueue) dequeue() (_Task, bool) {
+	for i := range q.lanes {
+		lane := &q.lanes[i]
+		if q.starvationLimit > 0 && lane.streak >= q.starvationLimit && q.backlogBelow(i) {
+			lane.streak = 0
+			continue
+		}
+		if task, ok := lane.pop(); ok {
+			lane.streak++
+			return task, true
+		}
+		lane.streak = 0
+	}
+	for i := range q.lanes {
+		if task, ok := q.lanes[i].pop(); ok {
+			return task, true
+		}
+	}
+	return _Task{}, false
+}
+
+func (q *_TaskQueue) backlogBelow(i int) bool {
+	for j := i + 1; j < len(q.lanes); j++ {
+		if q.lanes[j].hasHead || q.lanes[j].stats.queued.Load() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *_TaskQueue) backlog() int64 {
+	var n int64
+	for i := range q.lanes {
+		n += q.lanes[i].stats.queued.Load()
+	}
+	return n
+}
+
+// shift 取出仍留在队首的任务。
+func (q *_TaskQueue) shift() (_Task, bool) {
+	for i := range q.lanes {
+		if q.lanes[i].hasHead {
+			return q.lanes[i].pop()
+		}
+	}
+	return _Task{}, false
 }
 
-func (q *_TaskQueue) complete(typ TaskType, panicked bool) {
-	q.stats[typ].running.Add(-1)
-	q.stats[typ].completed.Add(1)
-	if panicked {
-		q.stats[typ].panicked.Add(1)
+// drain 在队列关闭后按优先级顺序执行剩余任务。
+func (q *_TaskQueue) drain(run func(task _Task)) {
+	for i := range q.lanes {
+		lane := &q.lanes[i]
+		if lane.hasHead {
+			task, _ := lane.pop()
+			run(task)
+		}
+		out := lane.out()
+		if out == nil {
+			continue
+		}
+		for task := range out {
+			run(task)
+		}
+	}
+	if q.frameChan != nil {
+		for task := range q.frameChan {
+			run(task)
+		}
+	}
+}
+
+// abandon 以 err 拒绝出队后不再执行的任务，并计为取消完成；合并任务同时释放其 key。
+func (q *_TaskQueue) abandon(task *_Task, err error) {
+	q.takeKeyed(task)
+	if task.stop != nil {
+		task.stop()
+	}
+	if task.guard != nil {
+		task.guard.cancel(err)
+	} else if !task.promise.IsNil() {
+		task.promise.Resolve(async.NewResult(nil, err))
+	}
+	q.skip(task)
+}
+
+func (q *_TaskQueue) start(task *_Task) {
+	for _, stats := range [...]*_TaskQueueStats{&q.stats[task.typ], &q.lanes[task.lane].stats} {
+		stats.waiting(task).Add(-1)
+		stats.running.Add(1)
+	}
+}
+
+// skip 将出队时已被撤回的任务直接记为取消完成。
+func (q *_TaskQueue) skip(task *_Task) {
+	for _, stats := range [...]*_TaskQueueStats{&q.stats[task.typ], &q.lanes[task.lane].stats} {
+		stats.waiting(task).Add(-1)
+		stats.completed.Add(1)
+		stats.canceled.Add(1)
+	}
+}
+
+func (q *_TaskQueue) complete(task *_Task, panicked bool) {
+	for _, stats := range [...]*_TaskQueueStats{&q.stats[task.typ], &q.lanes[task.lane].stats} {
+		stats.running.Add(-1)
+		stats.completed.Add(1)
+		if panicked {
+			stats.panicked.Add(1)
+		}
 	}
 }
 
 func (q *_TaskQueue) close() {
 	q.barrier.Close()
+	close(q.closing)
 	q.barrier.Wait()
-	if q.boundedChan != nil {
-		close(q.boundedChan)
+	for i := range q.lanes {
+		q.lanes[i].close()
 	}
-	if q.unboundedChan != nil {
-		q.unboundedChan.Close()
+	if q.frameChan != nil {
+		close(q.frameChan)
 	}
 }
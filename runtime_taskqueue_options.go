@@ -20,14 +20,20 @@
 package tiny
 
 import (
+	"time"
+
 	"git.golaxy.org/core/utils/exception"
 	"git.golaxy.org/core/utils/option"
 )
 
 // TaskQueueOptions 定义运行时任务队列的容量策略。
 type TaskQueueOptions struct {
-	Unbounded bool // 是否使用无界队列。
-	Capacity  int  // 有界队列容量；使用无界队列时忽略。
+	Unbounded        bool          // 是否使用无界队列。
+	Capacity         int           // 有界队列容量；启用优先级通道时为每个通道的容量，使用无界队列时忽略。
+	PriorityLanes    bool          // 是否启用 High/Normal/Low 优先级通道；未启用时所有任务共用一个队列。
+	StarvationLimit  int           // 高优先级通道连续出队达到该数量且低优先级通道有积压时，让出一次出队机会；0 表示严格按优先级出队。
+	FrameBudgetTasks int           // Realtime 模式下两帧之间最多执行的邮箱任务数；0 表示不限制。
+	FrameBudgetTime  time.Duration // Realtime 模式下两帧之间邮箱任务的最长累计执行时长；0 表示不限制。
 }
 
 type _TaskQueueOption struct{}
@@ -37,6 +43,10 @@ func (_TaskQueueOption) Default() option.Setting[TaskQueueOptions] {
 	return func(options *TaskQueueOptions) {
 		With.TaskQueue.Unbounded(true).Apply(options)
 		With.TaskQueue.Capacity(128).Apply(options)
+		With.TaskQueue.PriorityLanes(false).Apply(options)
+		With.TaskQueue.StarvationLimit(32).Apply(options)
+		With.TaskQueue.FrameBudgetTasks(0).Apply(options)
+		With.TaskQueue.FrameBudgetTime(0).Apply(options)
 	}
 }
 
@@ -56,3 +66,40 @@ func (_TaskQueueOption) Capacity(cap int) option.Setting[TaskQueueOptions] {
 		options.Capacity = cap
 	}
 }
+
+// PriorityLanes 设置是否启用优先级通道。
+func (_TaskQueueOption) PriorityLanes(b bool) option.Setting[TaskQueueOptions] {
+	return func(options *TaskQueueOptions) {
+		options.PriorityLanes = b
+	}
+}
+
+// StarvationLimit 设置优先级通道的防饥饿阈值，n 不能小于 0。
+func (_TaskQueueOption) StarvationLimit(n int) option.Setting[TaskQueueOptions] {
+	return func(options *TaskQueueOptions) {
+		if n < 0 {
+			exception.Panicf("%w: %w: StarvationLimit must be greater than or equal to 0", ErrRuntime, exception.ErrArgs)
+		}
+		options.StarvationLimit = n
+	}
+}
+
+// FrameBudgetTasks 设置 Realtime 模式下两帧之间最多执行的邮箱任务数；0 表示不限制，n 不能小于 0。
+func (_TaskQueueOption) FrameBudgetTasks(n int) option.Setting[TaskQueueOptions] {
+	return func(options *TaskQueueOptions) {
+		if n < 0 {
+			exception.Panicf("%w: %w: FrameBudgetTasks must be greater than or equal to 0", ErrRuntime, exception.ErrArgs)
+		}
+		options.FrameBudgetTasks = n
+	}
+}
+
+// FrameBudgetTime 设置 Realtime 模式下两帧之间邮箱任务的最长累计执行时长；0 表示不限制，dur 不能小于 0。
+func (_TaskQueueOption) FrameBudgetTime(dur time.Duration) option.Setting[TaskQueueOptions] {
+	return func(options *TaskQueueOptions) {
+		if dur < 0 {
+			exception.Panicf("%w: %w: FrameBudgetTime must be greater than or equal to 0", ErrRuntime, exception.ErrArgs)
+		}
+		options.FrameBudgetTime = dur
+	}
+}
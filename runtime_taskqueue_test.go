@@ -0,0 +1,348 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/core/utils/option"
+	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/clock"
+)
+
+func newTestTaskQueue(settings ...option.Setting[TaskQueueOptions]) *_TaskQueue {
+	q := &_TaskQueue{}
+	q.init(option.New(With.TaskQueue.Default(), settings...), clock.Real())
+	return q
+}
+
+func waitTestFuture(t *testing.T, future async.Future) async.Result {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	return future.Wait(ctx)
+}
+
+func TestTaskQueueSubmitCtxCanceledWhileWaitingForCapacity(t *testing.T) {
+	var executorID async.ExecutorID
+
+	for range 100 {
+		q := newTestTaskQueue(With.TaskQueue.Unbounded(false), With.TaskQueue.Capacity(1))
+		if err := q.enqueuePost(_Task{lane: runtime.TaskPriority_Normal, action: func(runtime.Context, ...any) {}}, nil); err != nil {
+			t.Fatalf("fill queue: %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		submitted := make(chan async.Future, 1)
+		go func() {
+			submitted <- q.enqueueSubmit(executorID, _Task{
+				lane: runtime.TaskPriority_Normal,
+				ctx:  ctx,
+				fun:  func(runtime.Context, ...any) async.Result { return async.NewResult(nil, nil) },
+			}, ctx)
+		}()
+		cancel()
+
+		ret := waitTestFuture(t, <-submitted)
+		if !errors.Is(ret.Error, context.Canceled) {
+			t.Fatalf("got %v, want %v", ret.Error, context.Canceled)
+		}
+	}
+}
+
+func TestTaskQueueSubmitCtxCanceledWhileQueueClosed(t *testing.T) {
+	var executorID async.ExecutorID
+
+	for range 100 {
+		q := newTestTaskQueue()
+		q.close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		future := q.enqueueSubmit(executorID, _Task{
+			lane: runtime.TaskPriority_Normal,
+			ctx:  ctx,
+			fun:  func(runtime.Context, ...any) async.Result { return async.NewResult(nil, nil) },
+		}, nil)
+
+		ret := waitTestFuture(t, future)
+		if !errors.Is(ret.Error, context.Canceled) && !errors.Is(ret.Error, ErrTaskQueueClosed) {
+			t.Fatalf("got %v, want %v or %v", ret.Error, context.Canceled, ErrTaskQueueClosed)
+		}
+	}
+}
+
+func dequeueTestTags(q *_TaskQueue, n int) []string {
+	var tags []string
+	for range n {
+		task, ok := q.dequeue()
+		if !ok {
+			break
+		}
+		q.start(&task)
+		tags = append(tags, task.args[0].(string))
+		q.complete(&task, false)
+	}
+	return tags
+}
+
+func postTestTag(t *testing.T, q *_TaskQueue, priority runtime.TaskPriority, tag string) {
+	t.Helper()
+	if err := q.enqueuePost(_Task{lane: priority, action: func(runtime.Context, ...any) {}, args: []any{tag}}, nil); err != nil {
+		t.Fatalf("post %s: %v", tag, err)
+	}
+}
+
+func TestTaskQueuePriorityOrder(t *testing.T) {
+	q := newTestTaskQueue(With.TaskQueue.Unbounded(false), With.TaskQueue.Capacity(8), With.TaskQueue.PriorityLanes(true), With.TaskQueue.StarvationLimit(0))
+
+	postTestTag(t, q, runtime.TaskPriority_Low, "l")
+	postTestTag(t, q, runtime.TaskPriority_Normal, "n")
+	postTestTag(t, q, runtime.TaskPriority_High, "h")
+
+	got := dequeueTestTags(q, 3)
+	want := []string{"h", "n", "l"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestTaskQueueStarvationLimit(t *testing.T) {
+	q := newTestTaskQueue(With.TaskQueue.Unbounded(false), With.TaskQueue.Capacity(8), With.TaskQueue.PriorityLanes(true), With.TaskQueue.StarvationLimit(2))
+
+	for range 4 {
+		postTestTag(t, q, runtime.TaskPriority_High, "h")
+	}
+	for range 2 {
+		postTestTag(t, q, runtime.TaskPriority_Low, "l")
+	}
+
+	got := dequeueTestTags(q, 6)
+	want := []string{"h", "h", "l", "h", "h", "l"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestTaskQueueLanesDisabled(t *testing.T) {
+	q := newTestTaskQueue(With.TaskQueue.Unbounded(false), With.TaskQueue.Capacity(8))
+
+	postTestTag(t, q, runtime.TaskPriority_Low, "l")
+	postTestTag(t, q, runtime.TaskPriority_High, "h")
+
+	got := dequeueTestTags(q, 2)
+	want := []string{"l", "h"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestInvalidTaskPriorityRejected(t *testing.T) {
+	rt := &RuntimeBehavior{}
+	invalid := runtime.TaskPriority_Low + 1
+
+	if err := rt.PostPriority(invalid, func(runtime.Context, ...any) {}); !errors.Is(err, ErrArgs) {
+		t.Fatalf("PostPriority: got %v, want %v", err, ErrArgs)
+	}
+	ret := waitTestFuture(t, rt.SubmitVoidPriority(invalid, func(runtime.Context, ...any) {}))
+	if !errors.Is(ret.Error, ErrArgs) {
+		t.Fatalf("SubmitVoidPriority: got %v, want %v", ret.Error, ErrArgs)
+	}
+}
+
+func TestTaskQueueAbandonReleasesKeyed(t *testing.T) {
+	q := newTestTaskQueue(With.TaskQueue.Unbounded(false), With.TaskQueue.Capacity(8))
+
+	if err := q.enqueuePostKeyed("k", _Task{action: func(runtime.Context, ...any) {}}); err != nil {
+		t.Fatalf("post keyed: %v", err)
+	}
+	task, ok := q.dequeue()
+	if !ok {
+		t.Fatal("dequeue: queue is empty")
+	}
+	q.abandon(&task, ErrTaskAbandoned)
+
+	if n := len(q.keyed); n != 0 {
+		t.Fatalf("keyed entries after abandon: got %d, want 0", n)
+	}
+}
+
+func TestTaskQueueCancelRacesStart(t *testing.T) {
+	ctx := runtime.NewContext()
+
+	for range 100 {
+		q := newTestTaskQueue(With.TaskQueue.Unbounded(false), With.TaskQueue.Capacity(1))
+
+		guard := &_TaskGuard{}
+		future := q.enqueueSubmit(ctx.ExecutorID(), _Task{
+			lane:  runtime.TaskPriority_Normal,
+			guard: guard,
+			fun: func(runtime.Context, ...any) async.Result {
+				return async.NewResult(1, nil)
+			},
+		}, nil)
+
+		handle := &_TaskHandle{guard: guard, future: future}
+		canceled := make(chan bool, 1)
+		go func() { canceled <- handle.Cancel() }()
+
+		task, ok := q.dequeue()
+		if !ok {
+			t.Fatal("dequeue: queue is empty")
+		}
+		started := task.begin()
+		if started {
+			task.run(ctx)
+		}
+
+		if c := <-canceled; c == started {
+			t.Fatalf("cancel %v and start %v must have exactly one winner", c, started)
+		}
+
+		ret := waitTestFuture(t, future)
+		if started && (ret.Error != nil || ret.Value != 1) {
+			t.Fatalf("started task result: got %v, %v", ret.Value, ret.Error)
+		}
+		if !started && !errors.Is(ret.Error, ErrTaskCanceled) {
+			t.Fatalf("canceled task result: got %v, want %v", ret.Error, ErrTaskCanceled)
+		}
+	}
+}
+
+func TestTaskQueueHeldTasksNotBacklog(t *testing.T) {
+	q := newTestTaskQueue(With.TaskQueue.Unbounded(false), With.TaskQueue.Capacity(8), With.TaskQueue.PriorityLanes(true), With.TaskQueue.StarvationLimit(1))
+
+	held := _Task{typ: TaskType_Post, lane: runtime.TaskPriority_Low, action: func(runtime.Context, ...any) {}}
+	if err := q.hold(&held); err != nil {
+		t.Fatalf("hold: %v", err)
+	}
+	if q.backlog() != 0 || q.backlogBelow(int(runtime.TaskPriority_High)) {
+		t.Fatal("held task counted as lane backlog")
+	}
+	if n := q.lanes[runtime.TaskPriority_Low].stats.held.Load(); n != 1 {
+		t.Fatalf("held: got %d, want 1", n)
+	}
+
+	q.start(&held)
+	q.complete(&held, false)
+	stats := &q.lanes[runtime.TaskPriority_Low].stats
+	if stats.held.Load() != 0 || stats.queued.Load() != 0 || stats.completed.Load() != 1 {
+		t.Fatalf("after run: held %d, queued %d, completed %d", stats.held.Load(), stats.queued.Load(), stats.completed.Load())
+	}
+}
+
+func TestTaskQueueInvalidPriorityInternalPaths(t *testing.T) {
+	q := newTestTaskQueue(With.TaskQueue.PriorityLanes(true))
+	invalid := runtime.TaskPriority_Low + 1
+
+	held := _Task{typ: TaskType_Post, lane: invalid}
+	if err := q.hold(&held); !errors.Is(err, ErrArgs) {
+		t.Fatalf("hold: got %v, want %v", err, ErrArgs)
+	}
+	if err := q.enqueuePostKeyed("k", _Task{lane: invalid, action: func(runtime.Context, ...any) {}}); !errors.Is(err, ErrArgs) {
+		t.Fatalf("enqueuePostKeyed: got %v, want %v", err, ErrArgs)
+	}
+	if len(q.keyed) != 0 {
+		t.Fatal("rejected keyed task left its key")
+	}
+}
+
+func TestTaskQueueWaitOnOwnerGoroutineFailsFast(t *testing.T) {
+	ctx := runtime.NewContext()
+	q := newTestTaskQueue(With.TaskQueue.Unbounded(false), With.TaskQueue.Capacity(1))
+
+	if err := q.enqueuePost(_Task{action: func(runtime.Context, ...any) {}}, nil); err != nil {
+		t.Fatalf("fill queue: %v", err)
+	}
+
+	type result struct {
+		postErr   error
+		submitRet async.Result
+	}
+	done := make(chan result, 1)
+	go func() {
+		q.setOwner(currentGoroutineID())
+		var ret result
+		ret.postErr = q.enqueuePost(_Task{action: func(runtime.Context, ...any) {}}, context.Background())
+		ret.submitRet = q.enqueueSubmit(ctx.ExecutorID(), _Task{action: func(runtime.Context, ...any) {}}, context.Background()).Wait(context.Background())
+		done <- ret
+	}()
+
+	select {
+	case ret := <-done:
+		if !errors.Is(ret.postErr, runtime.ErrRuntimeSelfWait) || !errors.Is(ret.postErr, ErrTaskQueueFull) {
+			t.Fatalf("PostWait on owner: got %v, want %v", ret.postErr, runtime.ErrRuntimeSelfWait)
+		}
+		if !errors.Is(ret.submitRet.Error, runtime.ErrRuntimeSelfWait) {
+			t.Fatalf("SubmitWait on owner: got %v, want %v", ret.submitRet.Error, runtime.ErrRuntimeSelfWait)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("wait on owner goroutine blocked on a full queue")
+	}
+}
+
+func TestTaskQueuePostKeyedCoalesces(t *testing.T) {
+	q := newTestTaskQueue(With.TaskQueue.Unbounded(false), With.TaskQueue.Capacity(8))
+
+	var got []string
+	for _, tag := range []string{"a", "b", "c"} {
+		if err := q.enqueuePostKeyed("k", _Task{action: func(_ runtime.Context, args ...any) {
+			got = append(got, args[0].(string))
+		}, args: []any{tag}}); err != nil {
+			t.Fatalf("post keyed %s: %v", tag, err)
+		}
+	}
+
+	task, ok := q.dequeue()
+	if !ok {
+		t.Fatal("dequeue: queue is empty")
+	}
+	if _, ok := q.dequeue(); ok {
+		t.Fatal("coalesced posts enqueued more than one task")
+	}
+	q.takeKeyed(&task)
+	task.action.UnsafeCall(nil, task.args...)
+
+	if !slices.Equal(got, []string{"c"}) {
+		t.Fatalf("ran %v, want [c]", got)
+	}
+	if n := q.stats[TaskType_Post].coalesced.Load(); n != 2 {
+		t.Fatalf("coalesced: got %d, want 2", n)
+	}
+	if len(q.keyed) != 0 {
+		t.Fatal("key not released after the task started")
+	}
+}
+
+func TestTaskQueuePostKeyedInvalidKey(t *testing.T) {
+	q := newTestTaskQueue()
+
+	for _, key := range []any{nil, []int{1}, map[string]int{}} {
+		if err := q.enqueuePostKeyed(key, _Task{action: func(runtime.Context, ...any) {}}); !errors.Is(err, ErrArgs) {
+			t.Fatalf("key %#v: got %v, want %v", key, err, ErrArgs)
+		}
+	}
+}
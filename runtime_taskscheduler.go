@@ -0,0 +1,173 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"container/heap"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"git.golaxy.org/tiny/utils/clock"
+)
+
+type _ScheduledTask struct {
+	task _Task
+	at   int64
+	seq  uint64
+}
+
+type _ScheduledTaskHeap []_ScheduledTask
+
+func (h _ScheduledTaskHeap) Len() int {
+	return len(h)
+}
+
+func (h _ScheduledTaskHeap) Less(i, j int) bool {
+	if h[i].at != h[j].at {
+		return h[i].at < h[j].at
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h _ScheduledTaskHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *_ScheduledTaskHeap) Push(x any) {
+	*h = append(*h, x.(_ScheduledTask))
+}
+
+func (h *_ScheduledTaskHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = _ScheduledTask{}
+	*h = old[:n-1]
+	return x
+}
+
+// popDue 按到期顺序取出所有 at 不大于 now 的任务。
+func (h *_ScheduledTaskHeap) popDue(now int64, tasks []_Task) []_Task {
+	for h.Len() > 0 && (*h)[0].at <= now {
+		tasks = append(tasks, heap.Pop(h).(_ScheduledTask).task)
+	}
+	return tasks
+}
+
+// _TaskScheduler 保存延迟到指定时间或帧号执行的邮箱任务，由 Runtime 主循环驱动，不为每个任务启动 goroutine。
+type _TaskScheduler struct {
+	mutex     sync.Mutex
+	timeline  _ScheduledTaskHeap
+	frameline _ScheduledTaskHeap
+	seq       uint64
+	clock     clock.Clock
+	timer     clock.Timer
+	timerAt   int64
+	frames    atomic.Int64
+	closed    bool
+}
+
+func (s *_TaskScheduler) init(c clock.Clock) {
+	s.clock = c
+	s.timer = c.NewTimer(math.MaxInt64)
+	s.timer.Stop()
+	s.timerAt = math.MaxInt64
+}
+
+func (s *_TaskScheduler) timerC() <-chan time.Time {
+	return s.timer.C()
+}
+
+// scheduleAt 将任务登记到 at（UnixNano）时刻执行。
+func (s *_TaskScheduler) scheduleAt(at time.Time, task _Task) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	s.seq++
+	heap.Push(&s.timeline, _ScheduledTask{task: task, at: at.UnixNano(), seq: s.seq})
+
+	if at.UnixNano() < s.timerAt {
+		s.timerAt = at.UnixNano()
+		s.timer.Reset(s.clock.Until(at))
+	}
+
+	return true
+}
+
+// scheduleAtFrame 将任务登记到已完成帧数达到 frame 后的首个帧循环开始时执行。
+func (s *_TaskScheduler) scheduleAtFrame(frame int64, task _Task) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	s.seq++
+	heap.Push(&s.frameline, _ScheduledTask{task: task, at: frame, seq: s.seq})
+
+	return true
+}
+
+// dueTasks 取出已到期的时间任务，并为下一个任务重新设置定时器。
+func (s *_TaskScheduler) dueTasks(now time.Time) []_Task {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tasks := s.timeline.popDue(now.UnixNano(), nil)
+
+	s.timerAt = math.MaxInt64
+	if s.timeline.Len() > 0 {
+		s.timerAt = s.timeline[0].at
+		s.timer.Reset(time.Duration(s.timerAt - now.UnixNano()))
+	}
+
+	return tasks
+}
+
+// dueFrameTasks 记录当前已完成帧数，并取出已到期的帧任务。
+func (s *_TaskScheduler) dueFrameTasks(curFrames int64) []_Task {
+	s.frames.Store(curFrames)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.frameline.popDue(curFrames, nil)
+}
+
+// close 停止接受新任务，并返回所有未到期的任务。
+func (s *_TaskScheduler) close() []_Task {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.closed = true
+	s.timer.Stop()
+
+	tasks := s.timeline.popDue(math.MaxInt64, nil)
+	tasks = s.frameline.popDue(math.MaxInt64, tasks)
+
+	return tasks
+}
@@ -0,0 +1,177 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/tiny/ec"
+	"git.golaxy.org/tiny/utils/id"
+)
+
+var (
+	ErrTaskAbandoned = fmt.Errorf("%w: task is abandoned on termination", ErrRuntime) // 优雅终止超过期限，任务未执行即被放弃。
+)
+
+// TerminationReport 优雅终止报告。
+type TerminationReport struct {
+	DrainedTasks    int64            // 终止时执行完成的积压任务数。
+	AbandonedTasks  int64            // 超过期限未执行、以 ErrTaskAbandoned 拒绝的任务数。
+	AbandonedScopes []AbandonedScope // 超过期限仍未结束的 AsyncScope。
+	Elapsed         time.Duration    // 从请求终止到运行时终止的耗时。
+}
+
+// AbandonedScope 描述超过期限仍未结束的 AsyncScope。
+// EntityID 为零值时表示运行时上下文的 Scope；Component 为空时表示实体的 Scope。
+type AbandonedScope struct {
+	EntityID  id.ID  // 所属实体 ID。
+	Component string // 组件名称。
+}
+
+// TerminateGracefully 请求停止运行时：不再接收新任务，在 ctx 结束前继续执行积压任务，之后的任务以 ErrTaskAbandoned 拒绝；
+// 随后关闭实体与组件的 AsyncScope 并在 ctx 结束前等待其完成。Future 在运行时终止后以 *TerminationReport 完成。
+// 重复调用返回同一个 Future，后续传入的 ctx 不生效；运行时已开始执行积压任务的退出流程后调用返回 ErrRuntimeNotRunning。
+func (rt *RuntimeBehavior) TerminateGracefully(ctx context.Context) async.Future {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !rt.isRunning.Load() {
+		return async.Rejected(ErrRuntimeNotRunning)
+	}
+
+	promise, future := async.NewPromise(rt.ctx.ExecutorID())
+	gt := &_GracefulTermination{
+		ctx:     ctx,
+		promise: promise,
+		future:  future,
+		begin:   rt.clock.Now(),
+	}
+
+	if !rt.gracefulTermination.CompareAndSwap(nil, gt) {
+		exists := rt.gracefulTermination.Load()
+		if exists == terminatedGracefulTermination {
+			return async.Rejected(ErrRuntimeNotRunning)
+		}
+		return exists.future
+	}
+
+	rt.Terminate()
+	return future
+}
+
+// _GracefulTermination 保存优雅终止请求，由 Runtime goroutine 在退出流程中读取。
+type _GracefulTermination struct {
+	ctx     context.Context
+	promise async.Promise
+	future  async.Future
+	begin   time.Time
+	report  TerminationReport
+}
+
+// terminatedGracefulTermination 标记运行时已开始执行积压任务的退出流程，之后的请求直接拒绝，
+// 避免请求错过积压任务与 AsyncScope 的处理而得到空报告。
+var terminatedGracefulTermination = &_GracefulTermination{}
+
+// drainTask 在期限内执行积压任务，超过期限后放弃。
+func (gt *_GracefulTermination) drainTask(rt *RuntimeBehavior) func(task _Task) {
+	return func(task _Task) {
+		if gt.ctx.Err() != nil {
+			rt.taskQueue.abandon(&task, ErrTaskAbandoned)
+			gt.report.AbandonedTasks++
+			return
+		}
+		rt.runTask(task)
+		gt.report.DrainedTasks++
+	}
+}
+
+// closeAsyncScopes 关闭实体、组件与运行时上下文的 AsyncScope，并在期限内等待其完成。
+func (gt *_GracefulTermination) closeAsyncScopes(rt *RuntimeBehavior) {
+	type _Scope struct {
+		scope *async.Scope
+		desc  AbandonedScope
+	}
+	var scopes []_Scope
+
+	rt.ctx.EntityManager().RangeEntities(func(entity ec.Entity) bool {
+		if scope := ec.UnsafeConcurrentEntity(entity).LoadedAsyncScope(); scope != nil {
+			scopes = append(scopes, _Scope{scope: scope, desc: AbandonedScope{EntityID: entity.ID()}})
+		}
+		entity.RangeComponents(func(comp ec.Component) bool {
+			if scope := ec.UnsafeConcurrentComponent(comp).LoadedAsyncScope(); scope != nil {
+				scopes = append(scopes, _Scope{scope: scope, desc: AbandonedScope{EntityID: entity.ID(), Component: comp.Name()}})
+			}
+			return true
+		})
+		return true
+	})
+	scopes = append(scopes, _Scope{scope: rt.ctx.AsyncScope()})
+
+	for i := range scopes {
+		scopes[i].scope.Close()
+	}
+	for i := range scopes {
+		if err := scopes[i].scope.Done().Wait(gt.ctx); err != nil {
+			gt.report.AbandonedScopes = append(gt.report.AbandonedScopes, scopes[i].desc)
+		}
+	}
+}
+
+func (gt *_GracefulTermination) finish(rt *RuntimeBehavior) {
+	gt.report.Elapsed = rt.clock.Since(gt.begin)
+	gt.promise.Resolve(async.NewResult(&gt.report, nil))
+}
+
+// loadGracefulTermination 返回生效的优雅终止请求；未请求时返回 nil。
+func (rt *RuntimeBehavior) loadGracefulTermination() *_GracefulTermination {
+	if gt := rt.gracefulTermination.Load(); gt != terminatedGracefulTermination {
+		return gt
+	}
+	return nil
+}
+
+func (rt *RuntimeBehavior) closeAsyncScopes() {
+	if gt := rt.loadGracefulTermination(); gt != nil {
+		gt.closeAsyncScopes(rt)
+		return
+	}
+	rt.ctx.AsyncScope().Close()
+	_ = rt.ctx.AsyncScope().Done().Wait(context.Background())
+}
+
+func (rt *RuntimeBehavior) finishGracefulTermination() {
+	if gt := rt.gracefulTermination.Swap(terminatedGracefulTermination); gt != nil && gt != terminatedGracefulTermination {
+		gt.finish(rt)
+	}
+}
+
+// drainTaskQueue 执行已关闭任务队列中的积压任务；请求优雅终止时按期限放弃剩余任务。
+// 未请求优雅终止时先标记退出流程已开始，此后的 TerminateGracefully 调用直接拒绝。
+func (rt *RuntimeBehavior) drainTaskQueue() {
+	rt.gracefulTermination.CompareAndSwap(nil, terminatedGracefulTermination)
+	if gt := rt.loadGracefulTermination(); gt != nil {
+		rt.taskQueue.drain(gt.drainTask(rt))
+		return
+	}
+	rt.taskQueue.drain(rt.runTask)
+}
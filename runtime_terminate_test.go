@@ -0,0 +1,152 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/tiny/ec"
+	"git.golaxy.org/tiny/runtime"
+)
+
+func waitTestTerminationReport(t *testing.T, future async.Future) *TerminationReport {
+	t.Helper()
+	ret := waitTestFuture(t, future)
+	if ret.Error != nil {
+		t.Fatalf("terminate gracefully: %v", ret.Error)
+	}
+	return ret.Value.(*TerminationReport)
+}
+
+func TestTerminateGracefullyAbandonsTasksPastDeadline(t *testing.T) {
+	rt := startTestRuntime(t, runtime.NewContext(), With.Runtime.Frame(With.Frame.Mode(FrameMode_Manual)))
+
+	const n = 32
+
+	deadline, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var drained int
+	var futures []async.Future
+
+	ret := waitTestFuture(t, rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		for range n {
+			futures = append(futures, rt.SubmitVoid(func(ctx runtime.Context, _ ...any) {
+				select {
+				case <-rt.taskQueue.closing:
+					// 首个积压任务执行后到达期限，剩余任务均须放弃
+					drained++
+					cancel()
+				default:
+				}
+			}))
+		}
+		return async.NewResult(rt.TerminateGracefully(deadline), nil)
+	}))
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+
+	report := waitTestTerminationReport(t, ret.Value.(async.Future))
+
+	var ran, abandoned int
+	for _, future := range futures {
+		switch err := waitTestFuture(t, future).Error; {
+		case err == nil:
+			ran++
+		case errors.Is(err, ErrTaskAbandoned):
+			abandoned++
+		default:
+			t.Fatalf("task: got %v, want nil or %v", err, ErrTaskAbandoned)
+		}
+	}
+
+	if drained != 1 || report.DrainedTasks != int64(drained) {
+		t.Fatalf("drained tasks: got report %d counted %d, want 1", report.DrainedTasks, drained)
+	}
+	if abandoned == 0 || report.AbandonedTasks != int64(abandoned) {
+		t.Fatalf("abandoned tasks: got report %d counted %d, want > 0 and equal", report.AbandonedTasks, abandoned)
+	}
+	if ran+abandoned != n {
+		t.Fatalf("tasks: got %d ran and %d abandoned, want %d in total", ran, abandoned, n)
+	}
+}
+
+func TestTerminateGracefullyReportsAbandonedScopes(t *testing.T) {
+	rtCtx := runtime.NewContext()
+	BuildEntityPT(rtCtx, "unit").Declare()
+	rt := startTestRuntime(t, rtCtx, With.Runtime.Frame(With.Frame.Mode(FrameMode_Manual)))
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	deadline, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	ret := waitTestFuture(t, rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		entity, err := BuildEntity(ctx, "unit").New()
+		if err != nil {
+			return async.NewResult(nil, err)
+		}
+		// 忽略 Scope 关闭，期限到达时仍未结束
+		SpawnVoid(entity, func(context.Context, ...any) {
+			select {
+			case <-release:
+			case <-time.After(200 * time.Millisecond):
+			}
+		})
+		return async.NewResult(entity, nil)
+	}))
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+	entity := ret.Value.(ec.Entity)
+
+	report := waitTestTerminationReport(t, rt.TerminateGracefully(deadline))
+
+	if !slices.Contains(report.AbandonedScopes, AbandonedScope{EntityID: entity.ID()}) {
+		t.Fatalf("abandoned scopes: got %v, want entity %s", report.AbandonedScopes, entity.ID())
+	}
+}
+
+func TestTerminateGracefullyRejectedAfterDrain(t *testing.T) {
+	rtCtx := runtime.NewContext()
+	rt := startTestRuntime(t, rtCtx, With.Runtime.Frame(With.Frame.Mode(FrameMode_Manual)))
+
+	late := make(chan async.Future, 1)
+
+	// 运行时上下文的 Scope 在积压任务执行完毕后关闭，此时请求优雅终止须被拒绝
+	SpawnVoid(rtCtx, func(ctx context.Context, _ ...any) {
+		<-ctx.Done()
+		late <- rt.TerminateGracefully(context.Background())
+	})
+
+	rt.Terminate()
+
+	ret := waitTestFuture(t, <-late)
+	if !errors.Is(ret.Error, ErrRuntimeNotRunning) {
+		t.Fatalf("late terminate gracefully: got %v, want %v", ret.Error, ErrRuntimeNotRunning)
+	}
+}
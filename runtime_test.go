@@ -0,0 +1,56 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.golaxy.org/core/utils/option"
+	"git.golaxy.org/tiny/runtime"
+)
+
+// startTestRuntime 创建并启动 Runtime，测试结束时终止并等待其退出。
+func startTestRuntime(t *testing.T, rtCtx runtime.Context, settings ...option.Setting[RuntimeOptions]) *RuntimeBehavior {
+	t.Helper()
+
+	rt := NewRuntime(rtCtx, settings...).(*RuntimeBehavior)
+
+	terminated := rt.Run()
+	t.Cleanup(func() {
+		rt.Terminate()
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		if err := terminated.Wait(ctx); err != nil {
+			t.Errorf("terminate runtime: %v", err)
+		}
+	})
+
+	return rt
+}
+
+// advanceTestFrames 在 Manual 模式下推进 frames 帧并等待完成。
+func advanceTestFrames(t *testing.T, rt *RuntimeBehavior, frames int64) {
+	t.Helper()
+	if ret := waitTestFuture(t, rt.AdvanceFrames(frames)); ret.Error != nil {
+		t.Fatalf("advance %d frames: %v", frames, ret.Error)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"time"
+
+	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/clock"
+)
+
+const (
+	noFrameTimersInterval = 10 * time.Millisecond // 未启用帧循环时推进定时器的间隔。
+)
+
+// runFrameTimers 在帧循环开始时推进定时器。
+// 时间定时器按模拟时间推进，随时间缩放变化，暂停期间不触发。
+func (rt *RuntimeBehavior) runFrameTimers() {
+	runtime.UnsafeContext(rt.ctx).AdvanceTimers(rt.frame.SimTime(), rt.frame.CurFrames())
+}
+
+// _NoFrameTimers 在未启用帧循环时按固定间隔推进定时器，没有等待中的定时器时停止计时。
+// 停止期间时间轮不再推进，重新计时时将起点平移到最近一次推进的位置，使空闲时长不计入新定时器的延时。
+type _NoFrameTimers struct {
+	rt        *RuntimeBehavior
+	beginTime time.Time
+	elapsed   time.Duration
+	ticker    clock.Ticker
+}
+
+func (t *_NoFrameTimers) init(rt *RuntimeBehavior) {
+	t.rt = rt
+	t.beginTime = rt.clock.Now()
+}
+
+func (t *_NoFrameTimers) tickC() <-chan time.Time {
+	if runtime.UnsafeContext(t.rt.ctx).CountTimers() <= 0 {
+		t.stop()
+		return nil
+	}
+	if t.ticker == nil {
+		t.beginTime = t.rt.clock.Now().Add(-t.elapsed)
+		t.ticker = t.rt.clock.NewTicker(noFrameTimersInterval)
+	}
+	return t.ticker.C()
+}
+
+func (t *_NoFrameTimers) run() {
+	t.elapsed = t.rt.clock.Since(t.beginTime)
+	runtime.UnsafeContext(t.rt.ctx).AdvanceTimers(t.elapsed, 0)
+}
+
+func (t *_NoFrameTimers) stop() {
+	if t.ticker != nil {
+		t.ticker.Stop()
+		t.ticker = nil
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"testing"
+	"time"
+
+	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/clock"
+)
+
+func TestNoFrameTimersResumeAfterIdle(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1000, 0))
+	ctx := runtime.NewContext()
+
+	var timers _NoFrameTimers
+	timers.init(&RuntimeBehavior{ctx: ctx, clock: fake})
+	defer timers.stop()
+
+	if timers.tickC() != nil {
+		t.Fatal("ticker running without timers")
+	}
+
+	fake.Advance(time.Minute)
+
+	fired := false
+	ctx.Timers().After(5*time.Second, func(runtime.Context) { fired = true })
+
+	if timers.tickC() == nil {
+		t.Fatal("ticker not restarted after adding a timer")
+	}
+
+	fake.Advance(noFrameTimersInterval)
+	timers.run()
+	if fired {
+		t.Fatal("timer fired right after resuming from idle")
+	}
+
+	fake.Advance(5 * time.Second)
+	timers.run()
+	if !fired {
+		t.Fatal("timer not fired after its delay")
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"git.golaxy.org/tiny/ec"
+)
+
+// ticking 判断实体在当前帧是否执行更新。更新间隔大于 1 时按实体 ID 错开，
+// 避免同一间隔的实体集中在同一帧更新。
+func (rt *RuntimeBehavior) ticking(entity ec.Entity) bool {
+	n := entity.UpdateInterval()
+	if n <= 1 {
+		return true
+	}
+	return (rt.frame.CurFrames()+int64(entity.ID()))%n == 0
+}
+
+// throttleUpdate 包装 Update 回调，按实体更新间隔执行，并在启用看门狗时标记正在执行的对象；
+// 更新间隔不大于 1 且未启用看门狗时直接返回 cb，更新间隔改变后由 Runtime 重新绑定。
+func (rt *RuntimeBehavior) throttleUpdate(entity ec.Entity, comp ec.Component, cb eventUpdate) eventUpdate {
+	subject := rt.watchdog.newSubject(entity, comp)
+	if subject == nil && entity.UpdateInterval() <= 1 {
+		return cb
+	}
+	return _HandleEventUpdate(func() {
+		if !rt.ticking(entity) {
+			return
+		}
+		rt.watchdog.enter(subject)
+		defer rt.watchdog.leave(subject)
+		cb.Update()
+	})
+}
+
+func (rt *RuntimeBehavior) throttleLateUpdate(entity ec.Entity, comp ec.Component, cb eventLateUpdate) eventLateUpdate {
+	subject := rt.watchdog.newSubject(entity, comp)
+	if subject == nil && entity.UpdateInterval() <= 1 {
+		return cb
+	}
+	return _HandleEventLateUpdate(func() {
+		if !rt.ticking(entity) {
+			return
+		}
+		rt.watchdog.enter(subject)
+		defer rt.watchdog.leave(subject)
+		cb.LateUpdate()
+	})
+}
+
+func (rt *RuntimeBehavior) throttlePhaseUpdate(entity ec.Entity, comp ec.Component, cb eventPhaseUpdate) eventPhaseUpdate {
+	subject := rt.watchdog.newSubject(entity, comp)
+	if subject == nil && entity.UpdateInterval() <= 1 {
+		return cb
+	}
+	return _EventPhaseUpdateHandler(func(phase string) {
+		if !rt.ticking(entity) {
+			return
+		}
+		rt.watchdog.enter(subject)
+		defer rt.watchdog.leave(subject)
+		cb.PhaseUpdate(phase)
+	})
+}
+
+// watchFixedUpdate 在启用看门狗时包装 FixedUpdate 回调以标记正在执行的对象；FixedUpdate 不按更新间隔降频。
+func (rt *RuntimeBehavior) watchFixedUpdate(entity ec.Entity, comp ec.Component, cb eventFixedUpdate) eventFixedUpdate {
+	subject := rt.watchdog.newSubject(entity, comp)
+	if subject == nil {
+		return cb
+	}
+	return _HandleEventFixedUpdate(func() {
+		rt.watchdog.enter(subject)
+		defer rt.watchdog.leave(subject)
+		cb.FixedUpdate()
+	})
+}
+
+// watchLockstepInput 在启用看门狗时包装锁步输入回调以标记正在执行的对象；锁步输入不按更新间隔降频。
+func (rt *RuntimeBehavior) watchLockstepInput(entity ec.Entity, comp ec.Component, cb eventLockstepInput) eventLockstepInput {
+	subject := rt.watchdog.newSubject(entity, comp)
+	if subject == nil {
+		return cb
+	}
+	return _HandleEventLockstepInput(func(frame int64, inputs []LockstepInput) {
+		rt.watchdog.enter(subject)
+		defer rt.watchdog.leave(subject)
+		cb.LockstepInput(frame, inputs)
+	})
+}
@@ -0,0 +1,81 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"testing"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/tiny/ec"
+	"git.golaxy.org/tiny/runtime"
+)
+
+type updateIntervalComp struct {
+	ec.ComponentBehavior
+	frames []int64
+}
+
+func (c *updateIntervalComp) Update() {
+	c.frames = append(c.frames, runtime.Current(c.Entity()).Frame().CurFrames())
+}
+
+func checkUpdateInterval(t *testing.T, frames []int64, n int64, ticks int) {
+	t.Helper()
+	if len(frames) != ticks {
+		t.Fatalf("interval %d: got %d ticks %v, want %d", n, len(frames), frames, ticks)
+	}
+	for i := 1; i < len(frames); i++ {
+		if frames[i]-frames[i-1] != n {
+			t.Fatalf("interval %d: ticks at frames %v", n, frames)
+		}
+	}
+}
+
+func TestUpdateIntervalTicksEveryNFrames(t *testing.T) {
+	rtCtx := runtime.NewContext()
+	BuildEntityPT(rtCtx, "unit").AddComponent(&updateIntervalComp{}, "comp").Declare()
+	rt := startTestRuntime(t, rtCtx, With.Runtime.Frame(With.Frame.Mode(FrameMode_Manual)))
+
+	const n = 4
+
+	ret := waitTestFuture(t, rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		return async.NewResult(BuildEntity(ctx, "unit").SetUpdateInterval(n).New())
+	}))
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+	entity := ret.Value.(ec.Entity)
+	comp := entity.GetComponent("comp").(*updateIntervalComp)
+
+	advanceTestFrames(t, rt, 3*n)
+	checkUpdateInterval(t, comp.frames, n, 3)
+
+	// 运行期间调整为每帧更新，再恢复间隔，回调须重新绑定
+	for _, interval := range []int64{1, n} {
+		waitTestFuture(t, rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+			entity.SetUpdateInterval(interval)
+			comp.frames = nil
+			return async.NewResult(nil, nil)
+		}))
+
+		advanceTestFrames(t, rt, 3*n)
+		checkUpdateInterval(t, comp.frames, interval, int(3*n/interval))
+	}
+}
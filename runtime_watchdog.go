@@ -0,0 +1,289 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"bytes"
+	"fmt"
+	goruntime "runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"git.golaxy.org/core/utils/generic"
+	"git.golaxy.org/tiny/ec"
+)
+
+var (
+	ErrWatchdogTimeout = fmt.Errorf("%w: watchdog timeout", ErrRuntime) // 单个任务执行超过看门狗阈值。
+)
+
+type (
+	WatchdogCB = generic.Action1[*WatchdogReport] // 看门狗检测到超时任务时调用的函数，在看门狗 goroutine 中执行。
+)
+
+// WatchdogReport 描述一次超过看门狗阈值的任务执行，可作为 error 使用并匹配 ErrWatchdogTimeout。
+type WatchdogReport struct {
+	TaskType  TaskType               // 超时任务的类型；帧循环为 TaskType_Frame。
+	Frames    int64                  // 任务开始时已完成的帧数；未启用帧循环时为 0。
+	Entity    ec.ConcurrentEntity    // 超时时正在执行帧回调的实体；未知时为 nil。
+	Component ec.ConcurrentComponent // 超时时正在执行帧回调的组件；未知时为 nil。
+	Elapsed   time.Duration          // 检测到超时时任务已执行的时长。
+	Stack     []byte                 // 检测到超时时 Runtime goroutine 的调用栈。
+}
+
+// Error 实现 error，返回包含任务、实体与组件信息的描述。
+func (r *WatchdogReport) Error() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s: task type %d, frames %d, elapsed %s", ErrWatchdogTimeout, r.TaskType, r.Frames, r.Elapsed)
+	if r.Entity != nil {
+		fmt.Fprintf(&buf, ", entity %s", r.Entity)
+	}
+	if r.Component != nil {
+		fmt.Fprintf(&buf, ", component %s", r.Component)
+	}
+	if len(r.Stack) > 0 {
+		buf.WriteString("\n")
+		buf.Write(r.Stack)
+	}
+	return buf.String()
+}
+
+// Unwrap 返回 ErrWatchdogTimeout。
+func (r *WatchdogReport) Unwrap() error {
+	return ErrWatchdogTimeout
+}
+
+// _WatchdogSubject 记录正在执行帧回调的实体与组件，在绑定回调时创建，执行时不再分配。
+type _WatchdogSubject struct {
+	entity    ec.ConcurrentEntity
+	component ec.ConcurrentComponent
+}
+
+// _Watchdog 在独立 goroutine 中检查 Runtime 当前任务的执行时长，每个超时任务只报告一次。
+type _Watchdog struct {
+	rt        *RuntimeBehavior
+	threshold time.Duration
+	gid       string
+	seq       atomic.Uint64
+	beginTime atomic.Int64
+	taskType  atomic.Int32
+	frames    atomic.Int64
+	subject   atomic.Pointer[_WatchdogSubject]
+	stopChan  chan struct{}
+	nextSeq   uint64
+	outer     []_WatchdogState
+}
+
+// _WatchdogState 保存被嵌套任务打断的外层任务状态，仅在 Runtime goroutine 中访问。
+type _WatchdogState struct {
+	seq       uint64
+	beginTime int64
+	taskType  int32
+	frames    int64
+	subject   *_WatchdogSubject
+}
+
+func (wd *_Watchdog) init(rt *RuntimeBehavior) {
+	wd.rt = rt
+	wd.threshold = rt.options.WatchdogThreshold
+}
+
+func (wd *_Watchdog) enabled() bool {
+	return wd.threshold > 0
+}
+
+// start 在 Runtime goroutine 中调用，记录 goroutine ID 并启动检查 goroutine。
+func (wd *_Watchdog) start() {
+	if !wd.enabled() {
+		return
+	}
+	wd.gid = currentGoroutineID()
+	wd.stopChan = make(chan struct{})
+	go wd.watching(wd.stopChan)
+}
+
+func (wd *_Watchdog) stop() {
+	if wd.stopChan != nil {
+		close(wd.stopChan)
+		wd.stopChan = nil
+	}
+}
+
+// begin 开始监视任务；帧任务中嵌套执行的任务会先保存外层任务状态，由 end 恢复。
+func (wd *_Watchdog) begin(typ TaskType) {
+	if !wd.enabled() {
+		return
+	}
+	if beginTime := wd.beginTime.Load(); beginTime != 0 {
+		wd.outer = append(wd.outer, _WatchdogState{
+			seq:       wd.seq.Load(),
+			beginTime: beginTime,
+			taskType:  wd.taskType.Load(),
+			frames:    wd.frames.Load(),
+			subject:   wd.subject.Load(),
+		})
+	}
+	if wd.rt.frame != nil {
+		wd.frames.Store(wd.rt.frame.CurFrames())
+	}
+	wd.taskType.Store(int32(typ))
+	wd.subject.Store(nil)
+	wd.nextSeq++
+	wd.seq.Store(wd.nextSeq)
+	wd.beginTime.Store(wd.rt.clock.Now().UnixNano())
+}
+
+// end 结束监视当前任务；存在外层任务时恢复其状态继续监视。须在 begin 后以 defer 调用。
+func (wd *_Watchdog) end() {
+	if !wd.enabled() {
+		return
+	}
+	if n := len(wd.outer); n > 0 {
+		state := wd.outer[n-1]
+		wd.outer = wd.outer[:n-1]
+		wd.frames.Store(state.frames)
+		wd.taskType.Store(state.taskType)
+		wd.subject.Store(state.subject)
+		wd.seq.Store(state.seq)
+		wd.beginTime.Store(state.beginTime)
+		return
+	}
+	wd.beginTime.Store(0)
+	wd.subject.Store(nil)
+}
+
+// newSubject 创建帧回调的看门狗标记；未启用看门狗时返回 nil。
+func (wd *_Watchdog) newSubject(entity ec.Entity, comp ec.Component) *_WatchdogSubject {
+	if !wd.enabled() {
+		return nil
+	}
+	return &_WatchdogSubject{entity: entity, component: comp}
+}
+
+// enter 标记正在执行帧回调的对象；须紧接以 defer 调用 leave，确保回调 panic 时也能清除标记。
+func (wd *_Watchdog) enter(subject *_WatchdogSubject) {
+	if subject != nil {
+		wd.subject.Store(subject)
+	}
+}
+
+func (wd *_Watchdog) leave(subject *_WatchdogSubject) {
+	if subject != nil {
+		wd.subject.CompareAndSwap(subject, nil)
+	}
+}
+
+func (wd *_Watchdog) watching(stopChan chan struct{}) {
+	ticker := wd.rt.clock.NewTicker(max(wd.threshold/4, time.Millisecond))
+	defer ticker.Stop()
+
+	var reported uint64
+
+	for {
+		select {
+		case <-ticker.C():
+		case <-stopChan:
+			return
+		}
+
+		beginTime := wd.beginTime.Load()
+		if beginTime == 0 {
+			continue
+		}
+
+		seq := wd.seq.Load()
+		if seq == reported {
+			continue
+		}
+
+		elapsed := wd.rt.clock.Since(time.Unix(0, beginTime))
+		if elapsed < wd.threshold {
+			continue
+		}
+
+		reported = seq
+		wd.report(elapsed)
+	}
+}
+
+func (wd *_Watchdog) report(elapsed time.Duration) {
+	report := &WatchdogReport{
+		TaskType: TaskType(wd.taskType.Load()),
+		Frames:   wd.frames.Load(),
+		Elapsed:  elapsed,
+		Stack:    goroutineStack(wd.gid),
+	}
+	if subject := wd.subject.Load(); subject != nil {
+		report.Entity = subject.entity
+		report.Component = subject.component
+	}
+
+	ctx := wd.rt.ctx
+
+	if wd.rt.options.WatchdogCB != nil {
+		wd.rt.options.WatchdogCB.Call(ctx.AutoRecover(), ctx.ReportError(), report)
+		return
+	}
+
+	if reportError := ctx.ReportError(); reportError != nil {
+		select {
+		case reportError <- report:
+		default:
+		}
+	}
+}
+
+func currentGoroutineID() string {
+	var buf [64]byte
+	stack := buf[:goruntime.Stack(buf[:], false)]
+	stack = bytes.TrimPrefix(stack, []byte("goroutine "))
+	if i := bytes.IndexByte(stack, ' '); i > 0 {
+		if _, err := strconv.ParseUint(string(stack[:i]), 10, 64); err == nil {
+			return string(stack[:i])
+		}
+	}
+	return ""
+}
+
+// goroutineStack 抓取全部 goroutine 的调用栈并截取 gid 对应的部分。
+func goroutineStack(gid string) []byte {
+	buf := make([]byte, 64*1024)
+	for {
+		n := goruntime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, len(buf)*2)
+	}
+
+	if gid == "" {
+		return buf
+	}
+
+	header := []byte("goroutine " + gid + " [")
+	for block := range bytes.SplitSeq(buf, []byte("\n\n")) {
+		if bytes.HasPrefix(block, header) {
+			return block
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"testing"
+	"time"
+
+	"git.golaxy.org/tiny/utils/clock"
+)
+
+func TestWatchdogNestedTaskRestoresOuter(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1000, 0))
+	wd := &_Watchdog{rt: &RuntimeBehavior{clock: fake}, threshold: time.Second}
+
+	wd.begin(TaskType_Frame)
+	outerBegin := wd.beginTime.Load()
+	outerSeq := wd.seq.Load()
+
+	fake.Advance(time.Millisecond)
+	wd.begin(TaskType_Post)
+	if got := TaskType(wd.taskType.Load()); got != TaskType_Post {
+		t.Fatalf("nested task type: got %v, want %v", got, TaskType_Post)
+	}
+	if wd.seq.Load() == outerSeq {
+		t.Fatal("nested task reuses outer seq")
+	}
+	wd.end()
+
+	if got := wd.beginTime.Load(); got != outerBegin {
+		t.Fatalf("outer begin time after nested end: got %d, want %d", got, outerBegin)
+	}
+	if got := TaskType(wd.taskType.Load()); got != TaskType_Frame {
+		t.Fatalf("outer task type after nested end: got %v, want %v", got, TaskType_Frame)
+	}
+	if got := wd.seq.Load(); got != outerSeq {
+		t.Fatalf("outer seq after nested end: got %d, want %d", got, outerSeq)
+	}
+
+	wd.end()
+	if got := wd.beginTime.Load(); got != 0 {
+		t.Fatalf("begin time after outer end: got %d, want 0", got)
+	}
+}
+
+func TestWatchdogLeaveOnPanic(t *testing.T) {
+	rt := &RuntimeBehavior{clock: clock.NewFake(time.Unix(1000, 0))}
+	rt.watchdog = _Watchdog{rt: rt, threshold: time.Second}
+
+	cb := rt.watchFixedUpdate(nil, nil, _HandleEventFixedUpdate(func() {
+		if rt.watchdog.subject.Load() == nil {
+			t.Error("subject not marked during callback")
+		}
+		panic("fixed update")
+	}))
+
+	rt.watchdog.begin(TaskType_Frame)
+	func() {
+		defer func() { recover() }()
+		cb.FixedUpdate()
+	}()
+
+	if rt.watchdog.subject.Load() != nil {
+		t.Fatal("subject still marked after callback panicked")
+	}
+	rt.watchdog.end()
+}
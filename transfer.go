@@ -0,0 +1,164 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"fmt"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/core/utils/corectx"
+	"git.golaxy.org/core/utils/exception"
+	"git.golaxy.org/tiny/ec"
+	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/id"
+)
+
+var (
+	ErrTransfer = fmt.Errorf("%w: transfer entity", ErrRuntime) // 实体迁移失败。
+)
+
+// TransferEntity 将 src 所属 Runtime 中的存活实体迁移到 dst 所属 Runtime。
+//
+// 迁移通过两个 Runtime 的邮箱完成：先在源 Runtime 中捕获实体、组件数据与 Meta，交由目标 Runtime 按原型名从其
+// EntityLib 重建实体并解码组件数据但不加入，目标拒绝时源实体保持不变，Future 直接以错误完成。校验通过后，
+// 源 Runtime 在同一个任务中重新捕获实体并按正常 Shut/Dispose 流程移除原实体，再由目标 Runtime 重建实体并分配新 ID，
+// 任一时刻至多只有一份存活的实体。目标 Runtime 此时仍拒绝重建时，源 Runtime 会按捕获的快照以原 ID 重新加入实体
+// （重新经历 Awake/Start），待其完成后 Future 才以错误完成；重新加入也失败时，错误同时包含两次失败的原因，
+// 原 ID 已被其他实体占用时为 runtime.ErrEntityReplaced。
+// 源实体不存在或已离开 Alive 状态时返回 runtime.ErrEntityNotAlive。拥有子实体的实体不能迁移，实体树关系不会保留。
+// codec 为 nil 时使用 runtime.JSONComponentCodec。Future 完成值为实体在目标 Runtime 中的新 ID。
+func TransferEntity(src, dst corectx.ConcurrentContextProvider, entityID id.ID, codec runtime.ComponentCodec) async.Future {
+	if src == nil {
+		exception.Panicf("%w: %w: src is nil", ErrCore, ErrArgs)
+	}
+	if dst == nil {
+		exception.Panicf("%w: %w: dst is nil", ErrCore, ErrArgs)
+	}
+	if codec == nil {
+		codec = runtime.JSONComponentCodec{}
+	}
+
+	srcRt := runtime.Concurrent(src)
+	dstRt := runtime.Concurrent(dst)
+	if srcRt.ExecutorID() == dstRt.ExecutorID() {
+		return async.Rejected(fmt.Errorf("%w: %w: src and dst are the same runtime", ErrTransfer, ErrArgs))
+	}
+
+	promise, future := async.NewPromise(srcRt.ExecutorID())
+	reject := func(err error) {
+		promise.Resolve(async.NewResult(nil, fmt.Errorf("%w: entity %q: %w", ErrTransfer, entityID, err)))
+	}
+
+	srcRt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		return captureTransferEntity(ctx, entityID, codec, false)
+	}).OnComplete(func(ret async.Result) {
+		if ret.Error != nil {
+			reject(ret.Error)
+			return
+		}
+		transferred := transferEntitySnapshot(ret.Value.(*runtime.EntitySnapshot))
+
+		// 先在目标中校验能否重建，失败时源实体保持不变
+		dstRt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+			return async.NewResult(nil, ctx.ValidateEntity(transferred, codec))
+		}).OnComplete(func(ret async.Result) {
+			if ret.Error != nil {
+				reject(ret.Error)
+				return
+			}
+
+			srcRt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+				return captureTransferEntity(ctx, entityID, codec, true)
+			}).OnComplete(func(ret async.Result) {
+				if ret.Error != nil {
+					reject(ret.Error)
+					return
+				}
+				entitySnapshot := ret.Value.(*runtime.EntitySnapshot)
+				transferred := transferEntitySnapshot(entitySnapshot)
+
+				dstRt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+					restored, err := ctx.RestoreEntity(transferred, codec)
+					if err != nil {
+						return async.NewResult(nil, err)
+					}
+					return async.NewResult(restored.ID(), nil)
+				}).OnComplete(func(ret async.Result) {
+					if ret.Error == nil {
+						promise.Resolve(ret)
+						return
+					}
+					dstErr := ret.Error
+
+					srcRt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+						if _, ok := ctx.EntityManager().GetEntity(entityID); ok {
+							return async.NewResult(nil, fmt.Errorf("%w: entity %q", runtime.ErrEntityReplaced, entityID))
+						}
+						_, err := ctx.RestoreEntity(entitySnapshot, codec)
+						return async.NewResult(nil, err)
+					}).OnComplete(func(ret async.Result) {
+						if ret.Error != nil {
+							reject(fmt.Errorf("%w; re-add to source: %w", dstErr, ret.Error))
+							return
+						}
+						reject(dstErr)
+					})
+				})
+			})
+		})
+	})
+
+	return future
+}
+
+// captureTransferEntity 检查并捕获待迁移的实体，remove 为 true 时在同一个任务中移除原实体。
+func captureTransferEntity(ctx runtime.Context, entityID id.ID, codec runtime.ComponentCodec, remove bool) async.Result {
+	entity, ok := ctx.EntityManager().GetEntity(entityID)
+	if !ok {
+		return async.NewResult(nil, fmt.Errorf("%w: entity %q not found", runtime.ErrEntityNotAlive, entityID))
+	}
+	if entity.State() > ec.EntityState_Alive {
+		return async.NewResult(nil, fmt.Errorf("%w: entity %q is in state %q", runtime.ErrEntityNotAlive, entityID, entity.State()))
+	}
+	if n, err := ctx.EntityTree().CountChildren(entityID); err == nil && n > 0 {
+		return async.NewResult(nil, fmt.Errorf("%w: entity has children", ErrArgs))
+	}
+
+	entitySnapshot, err := ctx.SnapshotEntity(entityID, codec)
+	if err != nil {
+		return async.NewResult(nil, err)
+	}
+	if remove {
+		ctx.EntityManager().RemoveEntity(entityID)
+	}
+	return async.NewResult(entitySnapshot, nil)
+}
+
+// transferEntitySnapshot 复制实体快照并清空 ID，目标中由运行时分配新 ID，原快照保留原 ID 供源 Runtime 重新加入。
+func transferEntitySnapshot(entitySnapshot *runtime.EntitySnapshot) *runtime.EntitySnapshot {
+	transferred := *entitySnapshot
+	transferred.ID = id.Nil
+	transferred.Components = make([]runtime.ComponentSnapshot, len(entitySnapshot.Components))
+	for i := range entitySnapshot.Components {
+		transferred.Components[i] = entitySnapshot.Components[i]
+		transferred.Components[i].ID = id.Nil
+	}
+	return &transferred
+}
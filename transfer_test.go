@@ -0,0 +1,161 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package tiny
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"git.golaxy.org/core/utils/async"
+	"git.golaxy.org/tiny/ec"
+	"git.golaxy.org/tiny/runtime"
+	"git.golaxy.org/tiny/utils/id"
+)
+
+type transferState struct {
+	ec.ComponentBehavior
+	N      int
+	awakes int
+}
+
+func (c *transferState) Awake() {
+	c.awakes++
+}
+
+var errTransferTestDecode = errors.New("transfer test decode")
+
+// transferFailingCodec 前 ok 次解码成功，之后的解码均失败。
+type transferFailingCodec struct {
+	runtime.JSONComponentCodec
+	ok    int64
+	calls atomic.Int64
+}
+
+func (codec *transferFailingCodec) Decode(comp ec.Component, data []byte) error {
+	if codec.calls.Add(1) > codec.ok {
+		return errTransferTestDecode
+	}
+	return codec.JSONComponentCodec.Decode(comp, data)
+}
+
+// startTransferTestRuntimes 启动源与目标 Runtime，并在源 Runtime 中创建 N 为 7 的实体；declareDst 为 false 时目标未声明实体原型。
+func startTransferTestRuntimes(t *testing.T, declareDst bool) (src, dst *RuntimeBehavior, entity ec.Entity) {
+	t.Helper()
+
+	srcCtx := runtime.NewContext()
+	BuildEntityPT(srcCtx, "unit").AddComponent(&transferState{}, "state").Declare()
+	dstCtx := runtime.NewContext()
+	if declareDst {
+		BuildEntityPT(dstCtx, "unit").AddComponent(&transferState{}, "state").Declare()
+	}
+
+	src = startTestRuntime(t, srcCtx)
+	dst = startTestRuntime(t, dstCtx)
+
+	ret := waitTestFuture(t, src.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		entity, err := BuildEntity(ctx, "unit").New()
+		if err != nil {
+			return async.NewResult(nil, err)
+		}
+		entity.GetComponent("state").(*transferState).N = 7
+		return async.NewResult(entity, nil)
+	}))
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+	return src, dst, ret.Value.(ec.Entity)
+}
+
+// getTransferTestEntity 在 rt 中查找实体，不存在时返回 nil。
+func getTransferTestEntity(t *testing.T, rt *RuntimeBehavior, entityID id.ID) ec.Entity {
+	t.Helper()
+	ret := waitTestFuture(t, rt.Submit(func(ctx runtime.Context, _ ...any) async.Result {
+		entity, _ := ctx.EntityManager().GetEntity(entityID)
+		return async.NewResult(entity, nil)
+	}))
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+	entity, _ := ret.Value.(ec.Entity)
+	return entity
+}
+
+func TestTransferEntity(t *testing.T) {
+	src, dst, entity := startTransferTestRuntimes(t, true)
+
+	ret := waitTestFuture(t, TransferEntity(src.ctx, dst.ctx, entity.ID(), nil))
+	if ret.Error != nil {
+		t.Fatal(ret.Error)
+	}
+	newID := ret.Value.(id.ID)
+
+	if getTransferTestEntity(t, src, entity.ID()) != nil {
+		t.Fatalf("source entity %s still exists", entity.ID())
+	}
+	transferred := getTransferTestEntity(t, dst, newID)
+	if transferred == nil {
+		t.Fatalf("target entity %s not found", newID)
+	}
+	if n := transferred.GetComponent("state").(*transferState).N; n != 7 {
+		t.Fatalf("transferred state: got %d, want 7", n)
+	}
+}
+
+func TestTransferEntityRejectedByTarget(t *testing.T) {
+	src, dst, entity := startTransferTestRuntimes(t, false)
+
+	ret := waitTestFuture(t, TransferEntity(src.ctx, dst.ctx, entity.ID(), nil))
+	if !errors.Is(ret.Error, ErrTransfer) || !errors.Is(ret.Error, runtime.ErrSnapshot) {
+		t.Fatalf("transfer: got %v, want %v and %v", ret.Error, ErrTransfer, runtime.ErrSnapshot)
+	}
+
+	// 目标校验失败时源实体保持不变，不会被移除后重新加入
+	if getTransferTestEntity(t, src, entity.ID()) != entity {
+		t.Fatalf("source entity %s was replaced", entity.ID())
+	}
+	state := entity.GetComponent("state").(*transferState)
+	if state.awakes != 1 || state.N != 7 {
+		t.Fatalf("source state: got awakes %d N %d, want 1 and 7", state.awakes, state.N)
+	}
+}
+
+func TestTransferEntityDoubleFailure(t *testing.T) {
+	src, dst, entity := startTransferTestRuntimes(t, true)
+
+	// 目标校验时解码成功，重建与源重新加入时解码均失败
+	codec := &transferFailingCodec{ok: 1}
+
+	ret := waitTestFuture(t, TransferEntity(src.ctx, dst.ctx, entity.ID(), codec))
+	if !errors.Is(ret.Error, ErrTransfer) || !errors.Is(ret.Error, errTransferTestDecode) {
+		t.Fatalf("transfer: got %v, want %v and %v", ret.Error, ErrTransfer, errTransferTestDecode)
+	}
+	if !strings.Contains(ret.Error.Error(), "re-add to source") {
+		t.Fatalf("transfer: got %v, want both failures reported", ret.Error)
+	}
+	if calls := codec.calls.Load(); calls != 3 {
+		t.Fatalf("decode calls: got %d, want 3", calls)
+	}
+
+	if getTransferTestEntity(t, src, entity.ID()) != nil {
+		t.Fatalf("source entity %s still exists", entity.ID())
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+// Clock 提供当前时间与计时器。
+type Clock interface {
+	// Now 返回当前时间。
+	Now() time.Time
+	// Since 返回自 t 以来经过的时长。
+	Since(t time.Time) time.Duration
+	// Until 返回距离 t 的时长。
+	Until(t time.Time) time.Duration
+	// NewTicker 创建周期为 d 的 Ticker，d 必须大于 0。
+	NewTicker(d time.Duration) Ticker
+	// NewTimer 创建在 d 后触发的 Timer。
+	NewTimer(d time.Duration) Timer
+	// AfterFunc 在 d 后执行 f，返回的 Timer 的 C 为 nil。
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Ticker 按固定周期向 C 发送当前时间。
+type Ticker interface {
+	// C 返回接收触发时间的通道。
+	C() <-chan time.Time
+	// Reset 停止 Ticker 并以新周期 d 重新开始计时。
+	Reset(d time.Duration)
+	// Stop 停止 Ticker。
+	Stop()
+}
+
+// Timer 在到期时向 C 发送当前时间或执行回调。
+type Timer interface {
+	// C 返回接收触发时间的通道；由 AfterFunc 创建时为 nil。
+	C() <-chan time.Time
+	// Reset 使 Timer 在 d 后重新触发，返回调用前 Timer 是否处于等待状态。
+	Reset(d time.Duration) bool
+	// Stop 停止 Timer，返回调用前 Timer 是否处于等待状态。
+	Stop() bool
+}
+
+// Provider 提供时钟。
+type Provider interface {
+	// Clock 返回时钟。
+	Clock() Clock
+}
+
+type _ContextKey struct{}
+
+// NewContext 返回携带时钟 c 的子上下文。
+func NewContext(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, _ContextKey{}, c)
+}
+
+// FromContext 返回 ctx 使用的时钟。ctx 实现 Provider 或通过 NewContext 携带时钟时返回该时钟，否则返回 Real。
+func FromContext(ctx context.Context) Clock {
+	if ctx == nil {
+		return Real()
+	}
+	if provider, ok := ctx.(Provider); ok {
+		if c := provider.Clock(); c != nil {
+			return c
+		}
+	}
+	if c, ok := ctx.Value(_ContextKey{}).(Clock); ok && c != nil {
+		return c
+	}
+	return Real()
+}
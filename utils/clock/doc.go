@@ -0,0 +1,28 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+// Package clock 抽象 Runtime 使用的时间源。
+/*
+Package clock 将读取当前时间、创建 Ticker 与 Timer 的操作抽象为 Clock 接口。
+
+Runtime、帧循环、GC 周期与 tiny.After/At/Every 均通过 Clock 取得时间。默认使用
+Real 返回的系统时钟；测试时可以改用 NewFake 创建的手动时钟，通过 Advance 推进时间，
+使 Realtime 模式、GC 间隔与超时行为可以被确定性地复现。
+*/
+package clock
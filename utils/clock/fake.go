@@ -0,0 +1,261 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package clock
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"git.golaxy.org/core/utils/exception"
+)
+
+// NewFake 创建以 now 为初始时间的手动时钟。
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Fake 是只在调用 Advance 或 Set 时前进的手动时钟，用于确定性测试。
+// 时间推进时，到期的 Ticker 与 Timer 按到期顺序依次触发；AfterFunc 的回调在推进时间的 goroutine 中同步执行。
+// 时长不大于 0 的 Timer 与 AfterFunc 在创建或 Reset 时立即触发，此时 AfterFunc 的回调与 time.AfterFunc 一样在新的 goroutine 中执行。
+type Fake struct {
+	mutex   sync.Mutex
+	now     time.Time
+	seq     uint64
+	waiters []*_FakeWaiter
+}
+
+type _FakeWaiter struct {
+	fake   *Fake
+	c      chan time.Time
+	fn     func()
+	when   time.Time
+	period time.Duration
+	seq    uint64
+	active bool
+}
+
+// Now 返回当前时间。
+func (f *Fake) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.now
+}
+
+// Since 返回自 t 以来经过的时长。
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// Until 返回距离 t 的时长。
+func (f *Fake) Until(t time.Time) time.Duration {
+	return t.Sub(f.Now())
+}
+
+// NewTicker 创建周期为 d 的 Ticker，d 必须大于 0。
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		exception.Panicf("%w: non-positive interval for NewTicker", exception.ErrArgs)
+	}
+	w := &_FakeWaiter{fake: f, c: make(chan time.Time, 1), period: d}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.schedule(w, d)
+	return (*_FakeTicker)(w)
+}
+
+// NewTimer 创建在 d 后触发的 Timer；d 不大于 0 时立即触发。
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	w := &_FakeWaiter{fake: f, c: make(chan time.Time, 1)}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.start(w, d)
+	return (*_FakeTimer)(w)
+}
+
+// AfterFunc 在 d 后执行 fn，返回的 Timer 的 C 为 nil；d 不大于 0 时立即在新的 goroutine 中执行。
+func (f *Fake) AfterFunc(d time.Duration, fn func()) Timer {
+	w := &_FakeWaiter{fake: f, fn: fn}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.start(w, d)
+	return (*_FakeTimer)(w)
+}
+
+// Advance 将时间向前推进 d，并依次触发期间到期的 Ticker 与 Timer。
+func (f *Fake) Advance(d time.Duration) {
+	f.Set(f.Now().Add(d))
+}
+
+// Set 将时间推进到 t，并依次触发期间到期的 Ticker 与 Timer；t 早于当前时间时不回退。
+func (f *Fake) Set(t time.Time) {
+	for {
+		f.mutex.Lock()
+		w := f.next(t)
+		if w == nil {
+			if t.After(f.now) {
+				f.now = t
+			}
+			f.mutex.Unlock()
+			return
+		}
+
+		f.now = w.when
+		now := f.now
+		fn := w.fn
+
+		if w.period > 0 {
+			f.schedule(w, w.period)
+		} else {
+			f.remove(w)
+		}
+
+		if w.c != nil {
+			select {
+			case w.c <- now:
+			default:
+			}
+		}
+		f.mutex.Unlock()
+
+		if fn != nil {
+			fn()
+		}
+	}
+}
+
+// Waiters 返回等待触发的 Ticker 与 Timer 数量，便于测试在推进时间前确认计时器已经创建。
+func (f *Fake) Waiters() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return len(f.waiters)
+}
+
+// next 返回不晚于 t 的最早到期计时器。
+func (f *Fake) next(t time.Time) *_FakeWaiter {
+	var next *_FakeWaiter
+	for _, w := range f.waiters {
+		if w.when.After(t) {
+			continue
+		}
+		if next == nil || w.when.Before(next.when) || (w.when.Equal(next.when) && w.seq < next.seq) {
+			next = w
+		}
+	}
+	return next
+}
+
+// start 启动单次计时器；d 不大于 0 时不进入等待列表，直接触发。
+func (f *Fake) start(w *_FakeWaiter, d time.Duration) {
+	if d > 0 {
+		f.schedule(w, d)
+		return
+	}
+	f.remove(w)
+	if w.c != nil {
+		select {
+		case w.c <- f.now:
+		default:
+		}
+	}
+	if w.fn != nil {
+		go w.fn()
+	}
+}
+
+func (f *Fake) schedule(w *_FakeWaiter, d time.Duration) {
+	f.seq++
+	w.when = f.now.Add(d)
+	w.seq = f.seq
+	if !w.active {
+		w.active = true
+		f.waiters = append(f.waiters, w)
+	}
+}
+
+func (f *Fake) remove(w *_FakeWaiter) bool {
+	if !w.active {
+		return false
+	}
+	w.active = false
+	f.waiters = slices.DeleteFunc(f.waiters, func(other *_FakeWaiter) bool { return other == w })
+	return true
+}
+
+// drain 丢弃通道中尚未接收的触发时间，与 Go 1.23 起 Stop、Reset 后不再收到旧值的语义一致。
+func (w *_FakeWaiter) drain() {
+	if w.c == nil {
+		return
+	}
+	select {
+	case <-w.c:
+	default:
+	}
+}
+
+type _FakeTicker _FakeWaiter
+
+func (t *_FakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *_FakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		exception.Panicf("%w: non-positive interval for Ticker.Reset", exception.ErrArgs)
+	}
+	f := t.fake
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	t.period = d
+	(*_FakeWaiter)(t).drain()
+	f.schedule((*_FakeWaiter)(t), d)
+}
+
+func (t *_FakeTicker) Stop() {
+	f := t.fake
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	(*_FakeWaiter)(t).drain()
+	f.remove((*_FakeWaiter)(t))
+}
+
+type _FakeTimer _FakeWaiter
+
+func (t *_FakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *_FakeTimer) Reset(d time.Duration) bool {
+	f := t.fake
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	active := t.active
+	(*_FakeWaiter)(t).drain()
+	f.start((*_FakeWaiter)(t), d)
+	return active
+}
+
+func (t *_FakeTimer) Stop() bool {
+	f := t.fake
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	(*_FakeWaiter)(t).drain()
+	return f.remove((*_FakeWaiter)(t))
+}
@@ -0,0 +1,156 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func expectTick(t *testing.T, c <-chan time.Time, want time.Time) {
+	t.Helper()
+	select {
+	case got := <-c:
+		if !got.Equal(want) {
+			t.Fatalf("tick: got %v, want %v", got, want)
+		}
+	default:
+		t.Fatalf("tick: none, want %v", want)
+	}
+}
+
+func expectNoTick(t *testing.T, c <-chan time.Time) {
+	t.Helper()
+	select {
+	case got := <-c:
+		t.Fatalf("tick: got %v, want none", got)
+	default:
+	}
+}
+
+func TestFakeTimerResetStop(t *testing.T) {
+	begin := time.Unix(1000, 0)
+	fake := NewFake(begin)
+
+	timer := fake.NewTimer(time.Second)
+	fake.Advance(time.Second)
+
+	// 已触发未接收的值在 Reset 后被丢弃
+	if timer.Reset(2 * time.Second) {
+		t.Fatal("Reset on fired timer reports active")
+	}
+	expectNoTick(t, timer.C())
+
+	fake.Advance(time.Second)
+	expectNoTick(t, timer.C())
+	fake.Advance(time.Second)
+	expectTick(t, timer.C(), begin.Add(3*time.Second))
+
+	timer.Reset(time.Second)
+	if !timer.Stop() {
+		t.Fatal("Stop on pending timer reports inactive")
+	}
+	if timer.Stop() {
+		t.Fatal("Stop on stopped timer reports active")
+	}
+	fake.Advance(time.Minute)
+	expectNoTick(t, timer.C())
+	if n := fake.Waiters(); n != 0 {
+		t.Fatalf("waiters after Stop: got %d, want 0", n)
+	}
+}
+
+func TestFakeTickerResetStop(t *testing.T) {
+	begin := time.Unix(1000, 0)
+	fake := NewFake(begin)
+
+	ticker := fake.NewTicker(time.Second)
+	fake.Advance(time.Second)
+	expectTick(t, ticker.C(), begin.Add(time.Second))
+
+	fake.Advance(time.Second)
+	ticker.Reset(3 * time.Second)
+	expectNoTick(t, ticker.C())
+
+	fake.Advance(2 * time.Second)
+	expectNoTick(t, ticker.C())
+	fake.Advance(time.Second)
+	expectTick(t, ticker.C(), begin.Add(5*time.Second))
+	fake.Advance(3 * time.Second)
+	expectTick(t, ticker.C(), begin.Add(8*time.Second))
+
+	fake.Advance(3 * time.Second)
+	ticker.Stop()
+	expectNoTick(t, ticker.C())
+	fake.Advance(time.Minute)
+	expectNoTick(t, ticker.C())
+	if n := fake.Waiters(); n != 0 {
+		t.Fatalf("waiters after Stop: got %d, want 0", n)
+	}
+}
+
+func TestFakeAfterFuncStop(t *testing.T) {
+	fake := NewFake(time.Unix(1000, 0))
+
+	var calls int
+	timer := fake.AfterFunc(time.Second, func() { calls++ })
+	fake.Advance(time.Second)
+	if calls != 1 {
+		t.Fatalf("calls after expiry: got %d, want 1", calls)
+	}
+
+	timer.Reset(time.Second)
+	if !timer.Stop() {
+		t.Fatal("Stop on pending AfterFunc reports inactive")
+	}
+	fake.Advance(time.Minute)
+	if calls != 1 {
+		t.Fatalf("calls after Stop: got %d, want 1", calls)
+	}
+}
+
+func TestFakeNonPositiveFiresImmediately(t *testing.T) {
+	begin := time.Unix(1000, 0)
+	fake := NewFake(begin)
+
+	for _, d := range []time.Duration{0, -time.Second} {
+		timer := fake.NewTimer(d)
+		expectTick(t, timer.C(), begin)
+		if timer.Stop() {
+			t.Fatalf("Stop on fired timer %s reports active", d)
+		}
+
+		fired := make(chan struct{})
+		fake.AfterFunc(d, func() { close(fired) })
+		select {
+		case <-fired:
+		case <-time.After(3 * time.Second):
+			t.Fatalf("AfterFunc(%s) not fired", d)
+		}
+	}
+
+	timer := fake.NewTimer(time.Second)
+	timer.Reset(0)
+	expectTick(t, timer.C(), begin)
+
+	if n := fake.Waiters(); n != 0 {
+		t.Fatalf("waiters: got %d, want 0", n)
+	}
+}
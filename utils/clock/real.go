@@ -0,0 +1,71 @@
+/*
+ * This file is part of Golaxy Distributed Service Development Framework.
+ *
+ * Golaxy Distributed Service Development Framework is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Lesser General Public License as published by
+ * the Free Software Foundation, either version 2.1 of the License, or
+ * (at your option) any later version.
+ *
+ * Golaxy Distributed Service Development Framework is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Lesser General Public License for more details.
+ *
+ * You should have received a copy of the GNU Lesser General Public License
+ * along with Golaxy Distributed Service Development Framework. If not, see <http://www.gnu.org/licenses/>.
+ *
+ * Copyright (c) 2024 pangdogs.
+ */
+
+package clock
+
+import "time"
+
+var realClock = _RealClock{}
+
+// Real 返回基于系统时间的时钟。
+func Real() Clock {
+	return realClock
+}
+
+type _RealClock struct{}
+
+func (_RealClock) Now() time.Time {
+	return time.Now()
+}
+
+func (_RealClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (_RealClock) Until(t time.Time) time.Duration {
+	return time.Until(t)
+}
+
+func (_RealClock) NewTicker(d time.Duration) Ticker {
+	return _RealTicker{Ticker: time.NewTicker(d)}
+}
+
+func (_RealClock) NewTimer(d time.Duration) Timer {
+	return _RealTimer{Timer: time.NewTimer(d)}
+}
+
+func (_RealClock) AfterFunc(d time.Duration, f func()) Timer {
+	return _RealTimer{Timer: time.AfterFunc(d, f)}
+}
+
+type _RealTicker struct {
+	*time.Ticker
+}
+
+func (t _RealTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+type _RealTimer struct {
+	*time.Timer
+}
+
+func (t _RealTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
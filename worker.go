@@ -20,6 +20,8 @@
 package tiny
 
 import (
+	"context"
+
 	"git.golaxy.org/core/utils/async"
 	"git.golaxy.org/core/utils/generic"
 	"git.golaxy.org/tiny/runtime"
@@ -30,6 +32,8 @@ type iWorker interface {
 	Run() async.Signal
 	// Terminate 请求停止并返回终止信号。
 	Terminate() async.Signal
+	// TerminateGracefully 在期限内排空任务并等待后台任务后停止，返回终止报告。
+	TerminateGracefully(ctx context.Context) async.Future
 	// Terminated 返回终止信号。
 	Terminated() async.Signal
 	// AdvanceFrames 在 Manual 模式下推进指定帧数。
@@ -38,4 +42,18 @@ type iWorker interface {
 	AdvanceToFrame(frame int64) async.Future
 	// AdvanceWhile 在 Manual 模式下持续推进，直到 predicate 返回 false。
 	AdvanceWhile(predicate generic.Func1[runtime.Context, bool]) async.Future
+	// Rollback 在 Manual 模式下回退到指定帧，执行修正后重新模拟到当前帧；重新模拟只重放帧回调。
+	Rollback(frame int64, correction generic.Action1[runtime.Context]) async.Future
+	// SubmitCommand 提交命名命令，并按记录器配置记录。
+	SubmitCommand(name string, args any) async.Future
+	// PostCommand 投递命名命令，并按记录器配置记录。
+	PostCommand(name string, args any) error
+	// ReplayCommands 在 Manual 模式下按记录的帧号回放命令。
+	ReplayCommands(commands []Command) async.Future
+	// AddLockstepParticipant 在 Lockstep 模式下注册参与者。
+	AddLockstepParticipant(participant string) async.Future
+	// RemoveLockstepParticipant 在 Lockstep 模式下注销参与者。
+	RemoveLockstepParticipant(participant string) async.Future
+	// SubmitInput 在 Lockstep 模式下提交参与者在指定帧的输入。
+	SubmitInput(participant string, frame int64, input any) async.Future
 }